@@ -0,0 +1,318 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"steel_tables/internal/analysis"
+	"steel_tables/internal/calc"
+	loadsPkg "steel_tables/internal/loads"
+	"steel_tables/internal/ui"
+)
+
+// stringList collects a repeated string flag.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func runCalcBeam(args []string) {
+	flags := flag.NewFlagSet("calc beam", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	var loads, patterns, permanent, imposed stringList
+	spansFlag := flags.String("spans", "", "comma-separated span lengths in m")
+	supportsFlag := flags.String("supports", "", "comma-separated supports: pin, roller, fixed or free (default: pin at every support)")
+	flags.Var(&loads, "load", "permanent load: udl:W, udl:W@S, udl:W@S:A-B or point:P@S:A (repeatable)")
+	flags.Var(&patterns, "pattern", "load applied span by span for the worst pattern, same forms as --load (repeatable)")
+	flags.Var(&permanent, "g", "permanent load case G for AS/NZS 1170.0 combinations, same forms as --load (repeatable)")
+	flags.Var(&imposed, "q", "imposed load case Q for AS/NZS 1170.0 combinations, patterned (repeatable)")
+	occupancyFlag := flags.String("occupancy", "office", "occupancy for the Table 4.1 ψ factors")
+	axis := flags.String("axis", "x", "bending axis: x or y")
+	limit := flags.Float64("limit", 250, "deflection limit as span/limit")
+	width := flags.Int("width", 64, "diagram width in characters")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc beam SECTION --spans M,M,... [--supports pin,...] [--load SPEC]... [--pattern SPEC]...")
+		flags.PrintDefaults()
+	}
+	match := findSection(flags, args, grade)
+	p := match.Property
+
+	if *spansFlag == "" {
+		log.Fatal("--spans is required")
+	}
+	spans, err := parseFloatList(*spansFlag)
+	if err != nil {
+		log.Fatalf("--spans: %v", err)
+	}
+	beam := analysis.Beam{Spans: spans, I: p.Ix}
+	if *axis == "y" {
+		beam.I = p.Iy
+	} else if *axis != "x" {
+		log.Fatalf("invalid axis '%s' (want x or y)", *axis)
+	}
+	if *supportsFlag == "" {
+		beam.Supports = make([]analysis.Support, len(spans)+1)
+	} else {
+		for _, name := range strings.Split(*supportsFlag, ",") {
+			s, err := analysis.ParseSupport(name)
+			if err != nil {
+				log.Fatal(err)
+			}
+			beam.Supports = append(beam.Supports, s)
+		}
+	}
+	for _, spec := range loads {
+		l, err := analysis.ParseBeamLoad(spec, len(spans), false)
+		if err != nil {
+			log.Fatal(err)
+		}
+		beam.Loads = append(beam.Loads, l...)
+	}
+	for _, spec := range patterns {
+		l, err := analysis.ParseBeamLoad(spec, len(spans), true)
+		if err != nil {
+			log.Fatal(err)
+		}
+		beam.Loads = append(beam.Loads, l...)
+	}
+
+	if len(permanent) == 0 && len(imposed) == 0 {
+		res, err := analysis.AnalyseBeam(beam)
+		if err != nil {
+			log.Fatal(err)
+		}
+		printBeamResult(os.Stdout, res, match.Table, p.Section, *axis, *limit, *width, calc.Section(p), nil)
+		return
+	}
+
+	if len(beam.Loads) > 0 {
+		log.Fatal("use either --load/--pattern or --g/--q, not both")
+	}
+	occupancy, err := loadsPkg.ParseOccupancy(*occupancyFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var cases []string
+	for _, c := range []struct {
+		name  string
+		specs stringList
+	}{{"G", permanent}, {"Q", imposed}} {
+		if len(c.specs) > 0 {
+			cases = append(cases, c.name)
+		}
+		for _, spec := range c.specs {
+			l, err := analysis.ParseBeamLoad(spec, len(spans), false)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for i := range l {
+				l[i].LoadCase = c.name
+			}
+			beam.Loads = append(beam.Loads, l...)
+		}
+	}
+	combos, err := loadsPkg.Generate(cases, occupancy)
+	if err != nil {
+		log.Fatal(err)
+	}
+	res, err := analysis.AnalyseBeamCombinations(beam, combos)
+	if err != nil {
+		log.Fatal(err)
+	}
+	printBeamResult(os.Stdout, res, match.Table, p.Section, *axis, *limit, *width, calc.Section(p), combos)
+}
+
+// printBeamResult prints a beam analysis. With combos the moment and shear
+// results are the strength envelope and the deflections the serviceability
+// envelope.
+func printBeamResult(w io.Writer, res *analysis.BeamResult, table, section, axis string, limit float64, width int, c calc.SectionCapacity, combos []loadsPkg.Combination) {
+	beam := res.Beam
+	r := newReport(w)
+	r.title(fmt.Sprintf("%s  [%s]  continuous beam", section, table))
+
+	r.section("Beam")
+	spans := make([]string, len(beam.Spans))
+	for i, l := range beam.Spans {
+		spans[i] = fmt.Sprintf("%g", l)
+	}
+	supports := make([]string, len(beam.Supports))
+	for i, s := range beam.Supports {
+		supports[i] = s.String()
+	}
+	r.line("Spans", strings.Join(spans, " + "), "m", "")
+	r.line("Supports", strings.Join(supports, ", "), "", "")
+	r.line("I"+axis, fmt.Sprintf("%.1f", beam.I), "10⁶mm⁴", fmt.Sprintf("E = %.0f MPa", calc.E))
+
+	r.section("Loads")
+	if len(beam.Loads) == 0 {
+		r.text("  None.")
+	}
+	for _, ld := range beam.Loads {
+		note := "permanent"
+		if ld.LoadCase != "" {
+			note = ld.LoadCase
+		} else if ld.Pattern {
+			note = "pattern"
+		}
+		if ld.Kind == analysis.PointLoad {
+			r.line(fmt.Sprintf("Span %d", ld.Span+1), fmt.Sprintf("P = %.2f kN at %.2f m", ld.Value, ld.Start), "", note)
+		} else if ld.End == 0 && ld.Start == 0 {
+			r.line(fmt.Sprintf("Span %d", ld.Span+1), fmt.Sprintf("w = %.2f kN/m", ld.Value), "", note)
+		} else {
+			r.line(fmt.Sprintf("Span %d", ld.Span+1), fmt.Sprintf("w = %.2f kN/m from %.2f to %.2f m", ld.Value, ld.Start, ld.End), "", note)
+		}
+	}
+
+	if len(combos) > 0 {
+		printCombinations(r, combos)
+	}
+
+	r.section("Reactions (upward +)")
+	rows := make([][]string, len(res.Reactions))
+	for i, re := range res.Reactions {
+		rows[i] = []string{fmt.Sprintf("%d", re.Support+1), fixed(re.X, 2),
+			fixed(re.Force.Min, 1), fixed(re.Force.Max, 1),
+			fixed(re.Moment.Min, 1), fixed(re.Moment.Max, 1)}
+	}
+	r.table([]string{"Support", "x (m)", "R min (kN)", "R max (kN)", "M min (kNm)", "M max (kNm)"}, rows)
+
+	r.section(fmt.Sprintf("Span envelopes (deflection limit L/%g)", limit))
+	rows = rows[:0]
+	maxM, maxV := 0.0, 0.0
+	for s, l := range beam.Spans {
+		x := res.Extremes(s)
+		maxM = max(maxM, x.Sagging, -x.Hogging)
+		maxV = max(maxV, x.Shear)
+		spanRatio := "-"
+		check := ""
+		if x.Deflection > 0 {
+			spanRatio = fmt.Sprintf("L/%.0f", l*1000/x.Deflection)
+			check = passFail(x.Deflection <= l*1000/limit)
+		}
+		rows = append(rows, []string{fmt.Sprintf("%d", s+1), fmt.Sprintf("%g", l),
+			extreme(x.Sagging, x.SaggingAt),
+			extreme(x.Hogging, x.HoggingAt),
+			fmt.Sprintf("%.1f", x.Shear),
+			fmt.Sprintf("%.1f @ %.2f", x.Deflection, x.DeflectAt),
+			spanRatio, check})
+	}
+	r.table([]string{"Span", "L (m)", "M+ (kNm)", "M- (kNm)", "V (kN)", "δ (mm)", "L/δ", ""}, rows)
+
+	capacityTitle := "Section capacity (loads as entered, no member buckling)"
+	if len(combos) > 0 {
+		capacityTitle = "Section capacity (strength envelope, no member buckling)"
+	}
+	r.section(capacityTitle)
+	phiM := c.PhiMsx
+	if axis == "y" {
+		phiM = c.PhiMsy
+	}
+	r.line("M*/φMs"+axis, fmt.Sprintf("%.1f / %.0f = %.3f", maxM, phiM, maxM/phiM), "", passFail(maxM <= phiM))
+	if axis == "x" {
+		r.line("V*/φVv", fmt.Sprintf("%.1f / %.0f = %.3f", maxV, c.PhiVv, maxV/c.PhiVv), "", passFail(maxV <= c.PhiVv))
+	}
+	r.flush()
+
+	xs := make([]float64, len(res.Stations))
+	vLo, vHi := make([]float64, len(xs)), make([]float64, len(xs))
+	mLo, mHi := make([]float64, len(xs)), make([]float64, len(xs))
+	dLo, dHi := make([]float64, len(xs)), make([]float64, len(xs))
+	for i, st := range res.Stations {
+		xs[i] = st.X
+		vLo[i], vHi[i] = st.Shear.Min, st.Shear.Max
+		mLo[i], mHi[i] = st.Moment.Min, st.Moment.Max
+		// Plot deflection downward.
+		dLo[i], dHi[i] = -st.Deflection.Max, -st.Deflection.Min
+	}
+	var positions []float64
+	var markers []string
+	x := 0.0
+	for i, s := range beam.Supports {
+		if i > 0 {
+			x += beam.Spans[i-1]
+		}
+		switch s {
+		case analysis.Fixed:
+			markers = append(markers, "█")
+		case analysis.Free:
+			markers = append(markers, "○")
+		default:
+			markers = append(markers, "▲")
+		}
+		positions = append(positions, x)
+	}
+	axisLine := ui.PlotAxis(0, beam.Length(), width, positions, markers)
+	for _, d := range []struct {
+		title  string
+		lo, hi []float64
+	}{
+		{"Shear force envelope (kN)", vLo, vHi},
+		{"Bending moment envelope (kNm, sagging +)", mLo, mHi},
+		{"Deflection envelope (mm, downward)", dLo, dHi},
+	} {
+		fmt.Fprintf(w, "\n%s\n", d.title)
+		for _, line := range ui.Plot(xs, d.lo, d.hi, width, 9) {
+			fmt.Fprintln(w, line)
+		}
+		fmt.Fprintln(w, axisLine)
+	}
+
+	r = newReport(w)
+	r.section("Results at tenth points")
+	rows = rows[:0]
+	start := 0.0
+	for s, l := range beam.Spans {
+		for k := 0; k <= 10; k++ {
+			if s > 0 && k == 0 {
+				continue
+			}
+			st := res.At(start + l*float64(k)/10)
+			rows = append(rows, []string{fmt.Sprintf("%d", s+1), fixed(st.X, 2),
+				fixed(st.Shear.Min, 1), fixed(st.Shear.Max, 1),
+				fixed(st.Moment.Min, 1), fixed(st.Moment.Max, 1),
+				fixed(st.Deflection.Max, 2)})
+		}
+		start += l
+	}
+	r.table([]string{"Span", "x (m)", "V min (kN)", "V max (kN)", "M min (kNm)", "M max (kNm)", "δ max (mm)"}, rows)
+	r.flush()
+}
+
+// extreme formats a span extreme with its position, or "-" if it is zero.
+func extreme(value, at float64) string {
+	text := fixed(value, 1)
+	if text == "0.0" {
+		return "-"
+	}
+	return fmt.Sprintf("%s @ %.2f", text, at)
+}
+
+// fixed formats a value with the given number of decimals without a
+// negative sign on zero.
+func fixed(value float64, decimals int) string {
+	text := fmt.Sprintf("%.*f", decimals, value)
+	if strings.Trim(text, "-0.") == "" {
+		return strings.TrimPrefix(text, "-")
+	}
+	return text
+}
+
+// printCombinations lists load combinations with their factors.
+func printCombinations(r *report, combos []loadsPkg.Combination) {
+	r.section("Load combinations (AS/NZS 1170.0)")
+	rows := make([][]string, len(combos))
+	for i, c := range combos {
+		rows[i] = []string{c.Name, c.State.String(), c.Clause, c.String()}
+	}
+	r.table([]string{"Name", "Limit state", "Clause", "Combination"}, rows)
+}
@@ -0,0 +1,719 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/angle"
+	"steel_tables/internal/calc"
+	"steel_tables/internal/catalog"
+	"steel_tables/internal/compound"
+	"steel_tables/internal/materials"
+	"steel_tables/internal/models"
+)
+
+func runCalc(args []string) {
+	if len(args) == 0 {
+		calcUsage()
+		os.Exit(2)
+	}
+	switch args[0] {
+	case "section":
+		runCalcSection(args[1:])
+	case "member":
+		runCalcMember(args[1:])
+	case "compression":
+		runCalcCompression(args[1:])
+	case "combined":
+		runCalcCombined(args[1:])
+	case "web":
+		runCalcWeb(args[1:])
+	case "classify":
+		runCalcClassify(args[1:])
+	case "tension":
+		runCalcTension(args[1:])
+	case "angle":
+		runCalcAngle(args[1:])
+	case "torsion":
+		runCalcTorsion(args[1:])
+	case "deflection":
+		runCalcDeflection(args[1:])
+	case "beam":
+		runCalcBeam(args[1:])
+	case "frame":
+		runCalcFrame(args[1:])
+	default:
+		calcUsage()
+		os.Exit(2)
+	}
+}
+
+func calcUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: steel_tables calc section SECTION [--grade N]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc member SECTION [--le M] [--am αm] [--grade N]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc compression SECTION [--lex M] [--ley M] [--grade N]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc combined SECTION --n kN --mx kNm [--my kNm] --lex M --ley M [--le M] [--grade N]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc web SECTION --v kN --r kN --bs MM [--end] [--grade N]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc classify SECTION [--fy MPa] [--grade N] | --table TABLE [--fy MPa]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc tension SECTION [--hole G@S]... [--d MM | --bolt M] [--element flange|web] [--count N] [--connection C] [--n kN]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc angle SECTION [--theta DEG,...] [--l M] [--connection long-leg|short-leg] [--n kN]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc torsion SECTION --length M [--ends pinned,pinned] [--t kNm@M]... [--m kNm/m] [--p kN@M]... [--w kN/m] [--ecc MM]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc deflection [SECTION] --span M [--support ss|cantilever|fixed] [--w kN/m] [--p kN@M]... [--limit N]")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc beam SECTION --spans M,M,... [--supports pin,...] [--load SPEC]... [--pattern SPEC]...")
+	fmt.Fprintln(os.Stderr, "       steel_tables calc frame MODEL.json [--case NAME]")
+}
+
+// sectionFlags adds the flags shared by every calc command that works on a
+// single catalog section.
+func sectionFlags(flags *flag.FlagSet) *int {
+	return flags.Int("grade", 0, "steel grade to pick when the section exists in several tables")
+}
+
+// findSection resolves the single positional designation argument.
+func findSection(flags *flag.FlagSet, args []string, grade *int) catalog.Match {
+	positional := parseArgs(flags, args)
+	if len(positional) != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	match, err := compound.FindOne(positional[0], *grade)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return match
+}
+
+func runCalcSection(args []string) {
+	flags := flag.NewFlagSet("calc section", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc section SECTION [--grade N]")
+		flags.PrintDefaults()
+	}
+	match := findSection(flags, args, grade)
+	p := match.Property
+	c := calc.Section(p)
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+	materialReport(r, p)
+	r.line("fy", num(c.Fy, 0), "MPa", "lower of flange and web")
+	r.line("fu", num(c.Fu, 0), "MPa", "")
+	r.line("kf", fmt.Sprintf("%.3f", c.Kf), "", "")
+
+	r.section("Section capacities (AS 4100)")
+	r.line("φMsx", num(c.PhiMsx, 1), "kNm", "Cl. 5.2, φ fy Zex")
+	zey := "Zey"
+	if p.Zey == 0 && p.MinorZe() > 0 {
+		zey = "min(ZeyL, ZeyR)"
+	}
+	r.line("φMsy", num(c.PhiMsy, 1), "kNm", "Cl. 5.2, φ fy "+zey)
+	r.line("φNs", num(c.PhiNs, 0), "kN", "Cl. 6.2, φ kf Ag fy")
+	r.line("φNt", num(c.PhiNt, 0), "kN", "Cl. 7.2, φ min(Ag fy, 0.85 Ag fu)")
+	shearNote := "Cl. 5.11, web yields in shear"
+	if !c.ShearYields {
+		shearNote = "Cl. 5.11, web shear buckling governs"
+	}
+	r.line("φVv", num(c.PhiVv, 0), "kN", shearNote)
+	r.line("Aw", num(c.Aw, 0), "mm²", "")
+	if c.WebSlender > 0 {
+		r.line("dp/tw", fmt.Sprintf("%.1f", c.WebSlender), "", "")
+	}
+	r.flush()
+}
+
+func runCalcMember(args []string) {
+	flags := flag.NewFlagSet("calc member", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	le := flags.Float64("le", 0, "effective length in m (omit to print only the capacity table)")
+	alphaM := flags.Float64("am", 1.0, "moment modification factor αm")
+	lengths := flags.String("lengths", "", "comma-separated effective lengths in m for the table")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc member SECTION [--le M] [--am αm] [--grade N]")
+		flags.PrintDefaults()
+	}
+	match := findSection(flags, args, grade)
+	p := match.Property
+
+	tableLengths := calc.DefaultLengths
+	if *lengths != "" {
+		var err error
+		if tableLengths, err = parseFloatList(*lengths); err != nil {
+			log.Fatalf("--lengths: %v", err)
+		}
+	}
+	table, err := calc.MemberMomentTable(p, *alphaM, tableLengths)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+	if *le > 0 {
+		m, err := calc.MemberMomentCapacity(p, *le, *alphaM)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r.section(fmt.Sprintf("Member moment capacity (AS 4100 Cl. 5.6), Le = %.2f m, αm = %.2f", m.Le, m.AlphaM))
+		r.line("Msx", num(m.Msx, 1), "kNm", "fy Zex")
+		r.line("Mo", num(m.Mo, 1), "kNm", "Cl. 5.6.1.1")
+		r.line("αs", fmt.Sprintf("%.3f", m.AlphaS), "", "")
+		r.line("φMbx", num(m.PhiMbx, 1), "kNm", "φ αm αs Msx ≤ φ Msx")
+	}
+
+	r.section(fmt.Sprintf("φMbx versus effective length, αm = %.2f", *alphaM))
+	rows := make([][]string, len(table))
+	for i, m := range table {
+		rows[i] = []string{fmt.Sprintf("%.1f", m.Le), num(m.Mo, 1), fmt.Sprintf("%.3f", m.AlphaS), num(m.PhiMbx, 1)}
+	}
+	r.table([]string{"Le (m)", "Mo (kNm)", "αs", "φMbx (kNm)"}, rows)
+	r.flush()
+}
+
+func runCalcCompression(args []string) {
+	flags := flag.NewFlagSet("calc compression", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	lex := flags.Float64("lex", 0, "effective length about the x axis in m")
+	ley := flags.Float64("ley", 0, "effective length about the y axis in m (default --lex)")
+	lengths := flags.String("lengths", "", "comma-separated effective lengths in m for the table")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc compression SECTION [--lex M] [--ley M] [--grade N]")
+		flags.PrintDefaults()
+	}
+	match := findSection(flags, args, grade)
+	p := match.Property
+	if *ley == 0 {
+		*ley = *lex
+	}
+
+	tableLengths := calc.DefaultLengths
+	if *lengths != "" {
+		var err error
+		if tableLengths, err = parseFloatList(*lengths); err != nil {
+			log.Fatalf("--lengths: %v", err)
+		}
+	}
+	table, err := calc.MemberCompressionTable(p, tableLengths)
+	if err != nil {
+		log.Fatal(err)
+	}
+	first := table[0]
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+	r.section("Member axial compression (AS 4100 Section 6)")
+	r.line("fy", num(first.Fy, 0), "MPa", "")
+	r.line("kf", fmt.Sprintf("%.3f", first.Kf), "", "")
+	r.line("αb", fmt.Sprintf("%.1f", first.AlphaB), "", first.AlphaBSource)
+	r.line("φNs", num(first.PhiNs, 0), "kN", "Cl. 6.2")
+
+	if *lex > 0 {
+		c, err := calc.MemberCompressionCapacity(p, *lex, *ley)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r.section(fmt.Sprintf("Lex = %.2f m, Ley = %.2f m", c.X.Le, c.Y.Le))
+		r.line("λnx", fmt.Sprintf("%.1f", c.X.LambdaN), "", "")
+		r.line("αcx", fmt.Sprintf("%.3f", c.X.AlphaC), "", "")
+		r.line("φNcx", num(c.X.PhiNc, 0), "kN", "Cl. 6.3.3")
+		r.line("λny", fmt.Sprintf("%.1f", c.Y.LambdaN), "", "")
+		r.line("αcy", fmt.Sprintf("%.3f", c.Y.AlphaC), "", "")
+		r.line("φNcy", num(c.Y.PhiNc, 0), "kN", "Cl. 6.3.3")
+	}
+
+	r.section("φNc versus effective length")
+	rows := make([][]string, len(table))
+	for i, c := range table {
+		rows[i] = []string{
+			fmt.Sprintf("%.1f", c.X.Le),
+			fmt.Sprintf("%.1f", c.X.LambdaN), fmt.Sprintf("%.3f", c.X.AlphaC), num(c.X.PhiNc, 0),
+			fmt.Sprintf("%.1f", c.Y.LambdaN), fmt.Sprintf("%.3f", c.Y.AlphaC), num(c.Y.PhiNc, 0),
+		}
+	}
+	r.table([]string{"Le (m)", "λnx", "αcx", "φNcx (kN)", "λny", "αcy", "φNcy (kN)"}, rows)
+	r.flush()
+}
+
+func runCalcCombined(args []string) {
+	flags := flag.NewFlagSet("calc combined", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	var a calc.CombinedActions
+	flags.Float64Var(&a.N, "n", 0, "design axial force N* in kN (compression positive, tension negative)")
+	flags.Float64Var(&a.Mx, "mx", 0, "design major axis moment Mx* in kNm")
+	flags.Float64Var(&a.My, "my", 0, "design minor axis moment My* in kNm")
+	flags.Float64Var(&a.Lex, "lex", 0, "compression effective length about x in m")
+	flags.Float64Var(&a.Ley, "ley", 0, "compression effective length about y in m")
+	flags.Float64Var(&a.Le, "le", 0, "lateral-torsional buckling effective length in m (default --ley)")
+	flags.Float64Var(&a.AlphaM, "am", 1, "moment modification factor αm")
+	flags.Float64Var(&a.BetaMx, "betamx", -1, "end moment ratio βm about x (-1 is conservative)")
+	flags.Float64Var(&a.BetaMy, "betamy", -1, "end moment ratio βm about y (-1 is conservative)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc combined SECTION --n kN --mx kNm [--my kNm] --lex M --ley M [--le M] [--grade N]")
+		flags.PrintDefaults()
+	}
+	match := findSection(flags, args, grade)
+	p := match.Property
+
+	res, err := calc.CheckCombined(p, a)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+	r.section("Design actions")
+	r.line("N*", fmt.Sprintf("%.1f", a.N), "kN", "compression positive")
+	r.line("Mx*", fmt.Sprintf("%.1f", a.Mx), "kNm", "")
+	r.line("My*", fmt.Sprintf("%.1f", a.My), "kNm", "")
+
+	r.section("Capacities (AS 4100 Section 8)")
+	if a.N > 0 {
+		r.line("φNs", num(res.PhiN, 0), "kN", "")
+		r.line("φNcx", num(res.PhiNcx, 0), "kN", "")
+		r.line("φNcy", num(res.PhiNcy, 0), "kN", "")
+	} else {
+		r.line("φNt", num(res.PhiN, 0), "kN", "")
+	}
+	r.line("φMrx", num(res.PhiMrx, 1), "kNm", "Cl. 8.3.2")
+	r.line("φMry", num(res.PhiMry, 1), "kNm", "Cl. 8.3.3")
+	r.line("φMix", num(res.PhiMix, 1), "kNm", "Cl. 8.4.2")
+	r.line("φMiy", num(res.PhiMiy, 1), "kNm", "Cl. 8.4.2")
+	r.line("φMox", num(res.PhiMox, 1), "kNm", "Cl. 8.4.4")
+
+	r.section("Interaction ratios")
+	rows := make([][]string, len(res.Checks))
+	for i, c := range res.Checks {
+		rows[i] = []string{c.Clause, c.Description, fmt.Sprintf("%.3f", c.Ratio), passFail(c.Passes())}
+	}
+	r.table([]string{"Clause", "Check", "Ratio", ""}, rows)
+	r.text(fmt.Sprintf("\nGoverning: Cl. %s %s, ratio %.3f — %s",
+		res.Governing.Clause, res.Governing.Description, res.Governing.Ratio, passFail(res.Governing.Passes())))
+	r.flush()
+}
+
+func runCalcWeb(args []string) {
+	flags := flag.NewFlagSet("calc web", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	var load calc.WebLoad
+	flags.Float64Var(&load.V, "v", 0, "design shear force V* in kN")
+	flags.Float64Var(&load.R, "r", 0, "design bearing force R* in kN")
+	flags.Float64Var(&load.Bearing, "bs", 0, "stiff bearing length in mm")
+	flags.BoolVar(&load.AtEnd, "end", false, "bearing at the member end (default: within the span)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc web SECTION --v kN --r kN --bs MM [--end] [--grade N]")
+		flags.PrintDefaults()
+	}
+	match := findSection(flags, args, grade)
+	p := match.Property
+
+	c, err := calc.CheckWeb(p, load)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	position := "interior"
+	if load.AtEnd {
+		position = "end"
+	}
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+	r.section("Web shear (AS 4100 Cl. 5.11)")
+	r.line("V*", fmt.Sprintf("%.1f", load.V), "kN", "")
+	r.line("φVv", num(c.PhiVv, 0), "kN", "")
+	r.line("V*/φVv", fmt.Sprintf("%.3f", c.ShearRatio), "", passFail(!c.DoublerRequired))
+
+	r.section(fmt.Sprintf("Web bearing (AS 4100 Cl. 5.13), bs = %.0f mm, %s", load.Bearing, position))
+	r.line("R*", fmt.Sprintf("%.1f", load.R), "kN", "")
+	r.line("bbf", num(c.Bbf, 0), "mm", "")
+	r.line("φRby", num(c.PhiRby, 0), "kN", "bearing yield, Cl. 5.13.3")
+	r.line("bb", num(c.Bb, 0), "mm", "")
+	r.line("λn", fmt.Sprintf("%.1f", c.LambdaN), "", "")
+	r.line("αc", fmt.Sprintf("%.3f", c.AlphaC), "", "αb = 0.5, kf = 1")
+	r.line("φRbb", num(c.PhiRbb, 0), "kN", "bearing buckling, Cl. 5.13.4")
+	r.line("R*/φRb", fmt.Sprintf("%.3f", c.BearingRatio), "", passFail(!c.StiffenerRequired))
+
+	r.section("Web reinforcement")
+	r.line("Doubler", c.Doubler, "", "table value")
+	r.line("Stiffener", c.Stiffener, "", "table value")
+	if c.DoublerRequired {
+		r.text("  Doubler plate required: V* exceeds φVv.")
+	}
+	if c.StiffenerRequired {
+		r.text("  Load-bearing stiffener required: R* exceeds φRb.")
+	}
+	if !c.DoublerRequired && !c.StiffenerRequired {
+		r.text("  No doubler plate or stiffener required.")
+	}
+	r.flush()
+}
+
+// pointLoads collects repeated --p P@A flags.
+type pointLoads []calc.PointLoad
+
+func (p *pointLoads) String() string {
+	parts := make([]string, len(*p))
+	for i, pl := range *p {
+		parts[i] = fmt.Sprintf("%g@%g", pl.P, pl.A)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (p *pointLoads) Set(value string) error {
+	pl, err := calc.ParsePointLoad(value)
+	if err != nil {
+		return err
+	}
+	*p = append(*p, pl)
+	return nil
+}
+
+// holes collects repeated --hole G@S flags.
+type holes []calc.Hole
+
+func (h *holes) String() string {
+	parts := make([]string, len(*h))
+	for i, hole := range *h {
+		parts[i] = fmt.Sprintf("%g@%g", hole.Gauge, hole.Pitch)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (h *holes) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		hole, err := calc.ParseHole(part)
+		if err != nil {
+			return err
+		}
+		*h = append(*h, hole)
+	}
+	return nil
+}
+
+func runCalcTension(args []string) {
+	flags := flag.NewFlagSet("calc tension", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	var pattern calc.HolePattern
+	var list holes
+	flags.Var(&list, "hole", "hole at gauge G and position S along the member, G@S in mm (repeatable, or comma-separated)")
+	flags.Float64Var(&pattern.Diameter, "d", 0, "hole diameter in mm (default from --bolt)")
+	bolt := flags.Float64("bolt", 20, "bolt diameter in mm; standard holes are 2 mm larger up to M24 and 3 mm above")
+	element := flags.String("element", "", "holed element: flange or web (default web for a web connection, else flange)")
+	flags.IntVar(&pattern.Count, "count", 1, "number of identical hole patterns across the section, e.g. 2 for both flanges")
+	connection := flags.String("connection", "uniform", "end connection: uniform, long-leg, short-leg (angles) or web (channels)")
+	n := flags.Float64("n", 0, "design tension N* in kN")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc tension SECTION [--hole G@S]... [--d MM | --bolt M] [--element flange|web] [--count N] [--connection C] [--n kN]")
+		flags.PrintDefaults()
+	}
+	match := findSection(flags, args, grade)
+	p := match.Property
+
+	conn, err := calc.ParseConnection(*connection)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if pattern.Diameter == 0 {
+		pattern.Diameter = *bolt + 2
+		if *bolt > 24 {
+			pattern.Diameter = *bolt + 3
+		}
+	}
+	if *element == "" {
+		*element = "flange"
+		if conn == calc.ConnectionWeb {
+			*element = "web"
+		}
+	}
+	switch *element {
+	case "flange":
+		pattern.Thickness = p.Tf
+	case "web":
+		pattern.Thickness = p.Tw
+	default:
+		log.Fatalf("invalid element '%s' (want flange or web)", *element)
+	}
+	pattern.Holes = list
+
+	t, err := calc.NetSectionTension(p, pattern, conn)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+	r.section("Net area (AS 4100 Cl. 7.3.1)")
+	r.line("Ag", num(p.Ag, 0), "mm²", "")
+	if len(pattern.Holes) > 0 {
+		r.line("dh", fmt.Sprintf("%g", pattern.Diameter), "mm", "")
+		r.line("t", fmt.Sprintf("%g", pattern.Thickness), "mm", *element)
+		chain := make([]string, len(t.Chain))
+		for i, h := range t.Chain {
+			chain[i] = fmt.Sprintf("%g@%g", h.Gauge, h.Pitch)
+		}
+		r.line("Chain", strings.Join(chain, " → "), "", fmt.Sprintf("critical of %d holes", len(pattern.Holes)))
+		note := "Σ dh t − Σ sp² t / 4sg"
+		if pattern.Count > 1 {
+			note = fmt.Sprintf("%s, × %d", note, pattern.Count)
+		}
+		r.line("Deduction", num(t.Deduction, 0), "mm²", note)
+	}
+	r.line("An", num(t.An, 0), "mm²", fmt.Sprintf("An/Ag = %.3f", t.An/p.Ag))
+
+	r.section("Tension capacity (AS 4100 Cl. 7.2)")
+	r.line("fy", num(t.Fy, 0), "MPa", "")
+	r.line("fu", num(t.Fu, 0), "MPa", "")
+	r.line("kt", fmt.Sprintf("%.2f", t.Kt), "", fmt.Sprintf("Cl. 7.3.2, %s connection", conn))
+	r.line("Ag fy", num(t.NtYield, 0), "kN", "")
+	r.line("0.85 kt An fu", num(t.NtFracture, 0), "kN", "")
+	r.line("φNt", num(t.PhiNt, 0), "kN", "φ min(Ag fy, 0.85 kt An fu)")
+	if *n > 0 {
+		ratio := *n / t.PhiNt
+		r.line("N*/φNt", fmt.Sprintf("%.3f", ratio), "", passFail(ratio <= 1))
+	}
+	r.flush()
+}
+
+func runCalcAngle(args []string) {
+	flags := flag.NewFlagSet("calc angle", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	thetas := flags.String("theta", "", "comma-separated axis angles in degrees anticlockwise from the n axis")
+	l := flags.Float64("l", 0, "strut length between end connections in m")
+	connection := flags.String("connection", string(calc.ConnectionLongLeg), "connected leg: long-leg or short-leg")
+	n := flags.Float64("n", 0, "design axial force N* in kN (compression positive, tension negative)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc angle SECTION [--theta DEG,...] [--l M] [--connection long-leg|short-leg] [--n kN]")
+		flags.PrintDefaults()
+	}
+	match := findSection(flags, args, grade)
+	p := match.Property
+
+	axes, err := angle.GetAxes(p)
+	if err != nil {
+		log.Fatal(err)
+	}
+	conn, err := calc.ParseConnection(*connection)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rotated := []angle.Axis{axes.N, axes.P, axes.X, axes.Y}
+	if *thetas != "" {
+		list, err := parseFloatList(*thetas)
+		if err != nil {
+			log.Fatalf("--theta: %v", err)
+		}
+		for _, theta := range list {
+			a, err := angle.About(p, theta)
+			if err != nil {
+				log.Fatal(err)
+			}
+			rotated = append(rotated, a)
+		}
+	}
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+	r.section("Axes (long leg vertical, heel at bottom left)")
+	r.line("nL", num(p.NL, 1), "mm", "centroid from the back of the vertical leg")
+	r.line("pB", num(p.PB, 1), "mm", "centroid from the back of the horizontal leg")
+	r.line("α", fmt.Sprintf("%.2f", axes.Alpha), "°", fmt.Sprintf("n to x, tan α = %.3f", p.TanAlpha))
+	r.line("Inp", fmt.Sprintf("%.3f", axes.Inp), "10⁶mm⁴", "product of inertia about n and p")
+
+	r.section("Properties about centroidal axes (θ from n, anticlockwise)")
+	rows := make([][]string, len(rotated))
+	for i, a := range rotated {
+		kind := "rotated"
+		switch i {
+		case 0, 1:
+			kind = "geometric"
+		case 2, 3:
+			kind = "principal"
+		}
+		rows[i] = []string{a.Name, kind, fmt.Sprintf("%.1f", a.Theta), fmt.Sprintf("%.3f", a.I),
+			fmt.Sprintf("%.2f", a.Z), fmt.Sprintf("%.1f", a.R), fmt.Sprintf("%.1f", a.Y)}
+	}
+	r.table([]string{"Axis", "", "θ (°)", "I (10⁶mm⁴)", "Z (10³mm³)", "r (mm)", "ymax (mm)"}, rows)
+	r.text(fmt.Sprintf("  Z is to the extreme fibre; the table's Zx = %s and Zy5 = %s (at the heel) are for comparison.",
+		num(p.Zx, 2), num(p.Zy5, 2)))
+
+	tension, err := calc.NetSectionTension(p, calc.HolePattern{}, conn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	r.section(fmt.Sprintf("Single angle connected by the %s", strings.ReplaceAll(string(conn), "-", " ")))
+	r.line("kt", fmt.Sprintf("%.2f", tension.Kt), "", "Cl. 7.3.2")
+	r.line("φNt", num(tension.PhiNt, 0), "kN", "Cl. 7.2, no holes (see calc tension)")
+	if *n < 0 {
+		ratio := -*n / tension.PhiNt
+		r.line("N*/φNt", fmt.Sprintf("%.3f", ratio), "", passFail(ratio <= 1))
+	}
+	if *l > 0 {
+		s, err := angle.CheckStrut(p, *l, conn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r.line("L", fmt.Sprintf("%.2f", s.L), "m", "")
+		r.line("ra", fmt.Sprintf("%.1f", s.Ra), "mm", "geometric axis parallel to the connected leg")
+		r.line("L/ra", fmt.Sprintf("%.1f", s.Slenderness), "", "")
+		r.line("(L/r)e", fmt.Sprintf("%.1f", s.Equivalent), "", "equivalent slenderness, AISC 360 Cl. E5")
+		r.line("λn", fmt.Sprintf("%.1f", s.LambdaN), "", "")
+		r.line("αc", fmt.Sprintf("%.3f", s.AlphaC), "", fmt.Sprintf("αb = %.1f", s.AlphaB))
+		r.line("φNs", num(s.PhiNs, 0), "kN", "Cl. 6.2")
+		r.line("φNc", num(s.PhiNc, 0), "kN", "Cl. 6.3.3")
+		if *n > 0 {
+			ratio := *n / s.PhiNc
+			r.line("N*/φNc", fmt.Sprintf("%.3f", ratio), "", passFail(ratio <= 1))
+		}
+	}
+	r.flush()
+}
+
+func runCalcDeflection(args []string) {
+	flags := flag.NewFlagSet("calc deflection", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	var beam calc.BeamLoading
+	var points pointLoads
+	support := flags.String("support", "ss", "support condition: ss, cantilever or fixed")
+	flags.Float64Var(&beam.Span, "span", 0, "span or cantilever length in m")
+	flags.Float64Var(&beam.UDL, "w", 0, "serviceability UDL in kN/m")
+	flags.Var(&points, "p", "point load P@A in kN at m from the left or fixed end (repeatable)")
+	limit := flags.Float64("limit", 250, "deflection limit as span/limit")
+	axis := flags.String("axis", "x", "bending axis: x or y")
+	table := flags.String("table", "", "list the sections in this table that pass")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc deflection [SECTION] --span M [--support ss|cantilever|fixed] [--w kN/m] [--p kN@M]... [--limit N]")
+		flags.PrintDefaults()
+	}
+	positional := parseArgs(flags, args)
+	if len(positional) > 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	s, err := calc.ParseSupport(*support)
+	if err != nil {
+		log.Fatal(err)
+	}
+	beam.Support = s
+	beam.Points = points
+	if *axis != "x" && *axis != "y" {
+		log.Fatalf("invalid axis '%s' (want x or y)", *axis)
+	}
+	secondMoment := func(p models.SteelProperty) float64 {
+		if *axis == "y" {
+			return p.Iy
+		}
+		return p.Ix
+	}
+
+	required, err := calc.RequiredIx(beam, *limit)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("Beam deflection: %s, L = %.2f m, limit L/%g", beam.Support, beam.Span, *limit))
+	r.section("Loads")
+	if beam.UDL != 0 {
+		r.line("w", fmt.Sprintf("%.2f", beam.UDL), "kN/m", "")
+	}
+	for _, pl := range beam.Points {
+		r.line("P", fmt.Sprintf("%.2f", pl.P), "kN", fmt.Sprintf("at %.2f m", pl.A))
+	}
+	r.section("Serviceability")
+	r.line("δ allowed", fmt.Sprintf("%.1f", beam.Span*1000 / *limit), "mm", "")
+	r.line("I"+*axis+" required", fmt.Sprintf("%.1f", required), "10⁶mm⁴", "")
+
+	if len(positional) == 1 {
+		match, err := compound.FindOne(positional[0], *grade)
+		if err != nil {
+			log.Fatal(err)
+		}
+		p := match.Property
+		d, err := calc.BeamDeflection(beam, secondMoment(p), *limit)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r.section(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+		r.line("I"+*axis, fmt.Sprintf("%.1f", secondMoment(p)), "10⁶mm⁴", "")
+		r.line("δ max", fmt.Sprintf("%.1f", d.Max), "mm", fmt.Sprintf("at %.2f m", d.At))
+		r.line("δ/δ allowed", fmt.Sprintf("%.3f", d.Ratio), "", passFail(d.Passes()))
+	}
+
+	if *table != "" {
+		rows, err := catalog.Load(*table)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var passing []models.SteelProperty
+		for _, p := range rows {
+			if secondMoment(p) >= required {
+				passing = append(passing, p)
+			}
+		}
+		sort.SliceStable(passing, func(i, j int) bool { return passing[i].Weight < passing[j].Weight })
+		r.section(fmt.Sprintf("Passing sections in %s, lightest first", strings.ToUpper(*table)))
+		if len(passing) == 0 {
+			r.text("  None.")
+		} else {
+			list := make([][]string, len(passing))
+			for i, p := range passing {
+				list[i] = []string{p.Section, fmt.Sprintf("%.1f", p.Weight), fmt.Sprintf("%.1f", secondMoment(p)),
+					fmt.Sprintf("%.3f", required/secondMoment(p))}
+			}
+			r.table([]string{"Section", "Weight (kg/m)", "I" + *axis + " (10⁶mm⁴)", "Ratio"}, list)
+		}
+	}
+	r.flush()
+}
+
+// materialReport prints a section's grade and the yield stress of its
+// flanges and web from their thicknesses.
+func materialReport(r *report, p models.SteelProperty) {
+	m := materials.Of(p)
+	r.section("Material")
+	if m.Grade != nil {
+		r.line("Grade", m.Grade.Name, "", string(m.Grade.Standard))
+	} else {
+		r.line("Grade", strconv.Itoa(p.Grade), "", "not in the materials database, table values")
+	}
+	thickness := func(t float64) string {
+		if t == 0 {
+			return "table value"
+		}
+		return fmt.Sprintf("t = %s mm", num(t, 1))
+	}
+	r.line("fy flange", num(m.Flange, 0), "MPa", thickness(m.FlangeThickness))
+	r.line("fy web", num(m.Web, 0), "MPa", thickness(m.WebThickness))
+}
+
+func passFail(ok bool) string {
+	if ok {
+		return "OK"
+	}
+	return "FAIL"
+}
+
+// parseFloatList parses a comma-separated list of numbers, which must not be
+// empty.
+func parseFloatList(list string) ([]float64, error) {
+	var values []float64
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", part)
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no numbers in '%s'", list)
+	}
+	return values, nil
+}
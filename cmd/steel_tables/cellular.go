@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"steel_tables/internal/catalog"
+	"steel_tables/internal/cellular"
+)
+
+func runCellular(args []string) {
+	flags := flag.NewFlagSet("cellular", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	kind := flags.String("kind", string(cellular.Cellular), "opening shape: cellular (circular) or castellated (hexagonal)")
+	var g cellular.Geometry
+	flags.Float64Var(&g.Depth, "depth", 0, "expanded depth Dg in mm")
+	flags.Float64Var(&g.Opening, "opening", 0, "opening diameter or height ho in mm")
+	flags.Float64Var(&g.Spacing, "spacing", 0, "opening centres in mm (default 1.5 ho, or regular hexagons)")
+	var a cellular.Actions
+	flags.Float64Var(&a.M, "m", 0, "design moment M* at the opening in kNm")
+	flags.Float64Var(&a.V, "v", 0, "design shear V* at the opening in kN")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables cellular PARENT --depth MM --opening MM [--spacing MM] [--m kNm] [--v kN] [--grade N]")
+		fmt.Fprintln(flags.Output(), "       steel_tables cellular PARENT --kind castellated (--depth MM | --opening MM) [--spacing MM] [...]")
+		flags.PrintDefaults()
+	}
+	positional := parseArgs(flags, args)
+	if len(positional) != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	match, err := catalog.FindOne(positional[0], *grade)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if g.Kind, err = cellular.ParseKind(*kind); err != nil {
+		log.Fatal(err)
+	}
+	b, err := cellular.Expand(match.Property, g)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", b.Name(), match.Table))
+	r.section("Geometry")
+	r.line("Dg", num(b.Depth, 0), "mm", fmt.Sprintf("parent d = %s mm", num(b.Parent.D, 0)))
+	r.line("ho", num(b.Opening, 0), "mm", fmt.Sprintf("ho/Dg = %.2f", b.Opening/b.Depth))
+	r.line("s", num(b.Spacing, 0), "mm", fmt.Sprintf("s/ho = %.2f", b.Spacing/b.Opening))
+	r.line("w", num(b.PostWidth, 0), "mm", "narrowest web post")
+	r.line("Tee depth", num(b.Tee.D, 1), "mm", "at the opening centreline")
+	r.line("Weight", num(b.Weight, 1), "kg/m", fmt.Sprintf("parent %s kg/m", num(b.Parent.Weight, 1)))
+
+	r.section("Section properties")
+	r.table([]string{"", "Ag (mm²)", "Ix (10⁶mm⁴)", "Zx (10³mm³)", "Sx (10³mm³)", "rx (mm)"}, [][]string{
+		{"Parent", num(b.Parent.Ag, 0), num(b.Parent.Ix, 1), num(b.Parent.Zx, 0), num(b.Parent.Sx, 0), num(b.Parent.Rx, 1)},
+		{"Gross", num(b.Gross.Ag, 0), num(b.Gross.Ix, 1), num(b.Gross.Zx, 0), num(b.Gross.Sx, 0), num(b.Gross.Rx, 1)},
+		{"Net", num(b.Net.Ag, 0), num(b.Net.Ix, 1), num(b.Net.Zx, 0), num(b.Net.Sx, 0), num(b.Net.Rx, 1)},
+	})
+	r.line("Iy", num(b.Iy, 2), "10⁶mm⁴", "gross")
+	r.line("J", num(b.J, 0), "10³mm⁴", "gross")
+	r.line("Iw", num(b.Iw, 0), "10⁹mm⁶", "gross")
+
+	r.section("Tee at the opening")
+	r.line("Ag", num(b.Tee.Ag, 0), "mm²", "")
+	r.line("yc", fmt.Sprintf("%v", b.Tee.PT), "mm", "centroid from the flange face")
+	r.line("Zex", num(b.Tee.Zex, 1), "10³mm³", fmt.Sprintf("%v", b.Tee.CNS))
+	r.line("kf", fmt.Sprintf("%v", b.Tee.Kf), "", "")
+
+	if a.M == 0 && a.V == 0 {
+		r.flush()
+		return
+	}
+	res, err := b.Check(a)
+	if err != nil {
+		log.Fatal(err)
+	}
+	r.section(fmt.Sprintf("Checks at an opening, M* = %.1f kNm, V* = %.1f kN", a.M, a.V))
+	r.line("Lever", num(res.Lever, 0), "mm", "between tee centroids")
+	r.line("N*T", fmt.Sprintf("%.1f", res.TeeForce), "kN", "M*/lever")
+	r.line("φNs,T", num(res.PhiNt, 0), "kN", "φ kf Ag fy")
+	r.line("φVo", num(res.PhiVo, 0), "kN", "φ 0.6 fyw (2 dT tw)")
+	r.line("M*v", fmt.Sprintf("%.1f", res.VierendeelMoment), "kNm", fmt.Sprintf("(V*/2)(ℓ/2), ℓ = %.0f mm", b.OpeningLength))
+	r.line("φMs,T", num(res.PhiMt, 1), "kNm", "φ fy Zex")
+	r.line("V*h", fmt.Sprintf("%.1f", res.PostShear), "kN", "V* s / lever")
+	r.line("φVh", num(res.PhiVh, 0), "kN", "φ 0.6 fyw w tw")
+	r.line("le", num(res.PostLe, 0), "mm", "0.5 √(w² + ho²)")
+	r.line("λn", fmt.Sprintf("%.1f", res.PostLambdaN), "", "")
+	r.line("αc", fmt.Sprintf("%.3f", res.PostAlphaC), "", "αb = 0.5, kf = 1")
+	r.line("φNwp", num(res.PhiNwp, 0), "kN", "φ αc w tw fyw")
+
+	rows := make([][]string, len(res.Checks))
+	for i, c := range res.Checks {
+		rows[i] = []string{c.Clause, c.Description, fmt.Sprintf("%.3f", c.Ratio), passFail(c.Passes())}
+	}
+	r.section("Ratios")
+	r.table([]string{"Clause", "Check", "Ratio", ""}, rows)
+	r.text(fmt.Sprintf("\nGoverning: %s, ratio %.3f — %s",
+		res.Governing.Description, res.Governing.Ratio, passFail(res.Governing.Passes())))
+	r.flush()
+}
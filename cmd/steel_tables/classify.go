@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/calc"
+	"steel_tables/internal/catalog"
+	"steel_tables/internal/classify"
+	"steel_tables/internal/compound"
+	"steel_tables/internal/models"
+)
+
+func runCalcClassify(args []string) {
+	flags := flag.NewFlagSet("calc classify", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	fy := flags.Float64("fy", 0, "yield stress in MPa (default: the grade's, the lower of flange and web)")
+	table := flags.String("table", "", "classify every section in this table and compare with the tabulated values")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc classify SECTION [--fy MPa] [--grade N]")
+		fmt.Fprintln(flags.Output(), "       steel_tables calc classify --table TABLE [--fy MPa]")
+		flags.PrintDefaults()
+	}
+	positional := parseArgs(flags, args)
+	if *table != "" && len(positional) == 0 {
+		classifyTable(*table, *fy)
+		return
+	}
+	if len(positional) != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	match, err := compound.FindOne(positional[0], *grade)
+	if err != nil {
+		log.Fatal(err)
+	}
+	p := match.Property
+	yield := *fy
+	if yield == 0 {
+		yield = calc.Yield(p)
+	}
+	res, err := classify.Section(p, yield)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+	materialReport(r, p)
+	r.line("fy", num(res.Fy, 0), "MPa", "used for classification")
+	r.line("Residual", res.Residual, "", "stress category for Table 5.2")
+
+	tabulated := res.Fy == p.MinYield()
+	axisReport := func(name string, a classify.Axis, class interface{}, ze float64) {
+		r.section(fmt.Sprintf("Bending about %s (Cl. 5.2)", name))
+		r.table([]string{"Element", "b (mm)", "t (mm)", "b/t", "λe", "λep", "λey", "Class"}, elementRows(a.Elements))
+		r.line("Governing", a.Governing.Name, "", fmt.Sprintf("λe/λey = %.2f", a.Governing.Lambda/a.Governing.Yield))
+		r.line("Z"+name, num(a.Z, 1), "10³mm³", "")
+		r.line("S"+name, num(a.S, 1), "10³mm³", "")
+		r.line("Zc", num(a.Zc, 1), "10³mm³", "min(S, 1.5Z)")
+		note := ""
+		if ze > 0 {
+			note = fmt.Sprintf("table %v, %s", class, num(ze, 1))
+			if tabulated {
+				note += fmt.Sprintf(" (%+.1f%%)", (a.Ze/ze-1)*100)
+			}
+		}
+		r.line("Ze"+name, num(a.Ze, 1), "10³mm³", fmt.Sprintf("%s; %s", a.Class, note))
+		r.line("φMs"+name, num(calc.PhiBending*res.Fy*a.Ze/1e3, 1), "kNm", "φ fy Ze")
+	}
+	axisReport("x", res.X, p.CNS, p.Zex)
+	if res.Y != nil {
+		axisReport("y", *res.Y, p.CNS2, p.Zey)
+	}
+
+	r.section("Form factor (Cl. 6.2.4)")
+	r.table([]string{"Element", "b (mm)", "t (mm)", "b/t", "λe", "λep", "λey", "Class"}, elementRows(res.Uniform))
+	r.line("kf", fmt.Sprintf("%.3f", res.Kf), "", fmt.Sprintf("table %.3f", p.FormFactor()))
+	r.line("φNs", num(calc.PhiCompression*res.Kf*p.Ag*res.Fy/1e3, 0), "kN", "φ kf Ag fy")
+	for _, n := range res.Notes {
+		r.text("\n  " + n)
+	}
+	r.flush()
+}
+
+func elementRows(elements []classify.Element) [][]string {
+	rows := make([][]string, len(elements))
+	for i, e := range elements {
+		rows[i] = []string{e.Name, num(e.Width, 1), num(e.Thickness, 1), fmt.Sprintf("%.1f", e.Width/e.Thickness),
+			fmt.Sprintf("%.1f", e.Lambda), num(e.Plastic, 0), num(e.Yield, 0), string(e.Class())}
+	}
+	return rows
+}
+
+// classifyTable classifies every row of a table at fy (or each row's own
+// yield stress when fy is 0) and lists the results beside the tabulated
+// values, flagging differences in class or of more than 2% in Ze or 0.01 in
+// kf.
+func classifyTable(table string, fy float64) {
+	rows, err := catalog.Load(table)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var list [][]string
+	differ := 0
+	for _, p := range rows {
+		yield := fy
+		if yield == 0 {
+			yield = calc.Yield(p)
+		}
+		res, err := classify.Section(p, yield)
+		if err != nil {
+			log.Fatal(err)
+		}
+		flag := ""
+		if yield == p.MinYield() && !matchesTable(p, res) {
+			flag = "≠"
+			differ++
+		}
+		zey, classY := "-", "-"
+		if res.Y != nil {
+			zey, classY = sig(res.Y.Ze), string(res.Y.Class)
+		}
+		list = append(list, []string{
+			p.Section, num(yield, 0),
+			fmt.Sprintf("%v", p.CNS), string(res.X.Class), sig(p.Zex), sig(res.X.Ze),
+			fmt.Sprintf("%v", orDash(p.CNS2)), classY, sig(p.Zey), zey,
+			fmt.Sprintf("%.3f", p.FormFactor()), fmt.Sprintf("%.3f", res.Kf), flag,
+		})
+	}
+
+	r := newReport(os.Stdout)
+	title := fmt.Sprintf("Section classification: %s", strings.ToUpper(table))
+	if fy > 0 {
+		title += fmt.Sprintf(" at fy = %s MPa", num(fy, 0))
+	}
+	r.title(title)
+	r.table([]string{"Section", "fy", "Table x", "x", "Table Zex", "Zex", "Table y", "y", "Table Zey", "Zey", "Table kf", "kf", ""}, list)
+	if fy == 0 {
+		r.text(fmt.Sprintf("\n  %d of %d rows differ from the table (≠).", differ, len(rows)))
+	}
+	r.flush()
+}
+
+// matchesTable reports whether a classification at the table's yield stress
+// agrees with the tabulated class, Ze and kf.
+func matchesTable(p models.SteelProperty, res classify.Result) bool {
+	near := func(a, b, tol float64) bool { return b == 0 || math.Abs(a-b) <= tol*b }
+	if fmt.Sprint(p.CNS) != string(res.X.Class) || !near(res.X.Ze, p.Zex, 0.02) {
+		return false
+	}
+	if res.Y != nil && p.Zey > 0 && (fmt.Sprint(p.CNS2) != string(res.Y.Class) || !near(res.Y.Ze, p.Zey, 0.02)) {
+		return false
+	}
+	return math.Abs(res.Kf-p.FormFactor()) <= 0.01
+}
+
+// sig formats a modulus to three significant figures like the tables.
+func sig(v float64) string {
+	if v == 0 {
+		return "-"
+	}
+	scale := math.Pow(10, 2-math.Floor(math.Log10(math.Abs(v))))
+	return strconv.FormatFloat(math.Round(v*scale)/scale, 'f', -1, 64)
+}
+
+func orDash(v interface{}) interface{} {
+	if v == nil {
+		return "-"
+	}
+	return v
+}
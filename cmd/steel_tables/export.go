@@ -0,0 +1,195 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"steel_tables/internal/catalog"
+	"steel_tables/internal/columns"
+	"steel_tables/internal/export"
+	"steel_tables/internal/models"
+	"steel_tables/internal/query"
+	"steel_tables/internal/ui"
+	"steel_tables/internal/viewer"
+)
+
+func runExport(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	formatFlag := flags.String("format", "", "output format: "+strings.Join(export.Formats(), ", ")+" (default from --output extension, else csv)")
+	columnsFlag := flags.String("columns", "", "comma-separated columns to export (default: all columns with data)")
+	whereFlag := flags.String("where", "", "comma-separated filters, e.g. \"d>=300,Weight<60\"")
+	sortFlag := flags.String("sort", "", "column to sort by; prefix with - for descending")
+	allFlag := flags.Bool("all", false, "export every table in the catalog")
+	var output string
+	flags.StringVar(&output, "output", "", "write to file instead of stdout")
+	flags.StringVar(&output, "o", "", "shorthand for --output")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables export TABLE [TABLE...] [flags]")
+		fmt.Fprintln(flags.Output(), "       steel_tables export --all [flags]")
+		flags.PrintDefaults()
+	}
+	tableNames := parseArgs(flags, args)
+	if *allFlag {
+		all, err := catalog.Tables()
+		if err != nil {
+			log.Fatal(err)
+		}
+		tableNames = append(tableNames, all...)
+	}
+	tableNames = uniqueNames(tableNames)
+	if len(tableNames) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	format := exportFormat(*formatFlag, output)
+
+	conditions, err := query.ParseWhere(*whereFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var tables []export.Table
+	for _, name := range tableNames {
+		table, err := buildExportTable(name, conditions, *sortFlag, *columnsFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tables = append(tables, table)
+	}
+
+	writeExport(output, format, tables)
+}
+
+// uniqueNames drops repeated tables, however they are written, keeping the
+// first of each so a table named on the command line and by --all is
+// exported once.
+func uniqueNames(names []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, name := range names {
+		if file := catalog.TableFile(name); !seen[file] {
+			seen[file] = true
+			unique = append(unique, name)
+		}
+	}
+	return unique
+}
+
+// exportFormat returns the format named by --format, else the one implied by
+// the output file extension, else CSV.
+func exportFormat(formatFlag, output string) export.Format {
+	if formatFlag != "" {
+		f, err := export.ParseFormat(formatFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return f
+	}
+	if f, ok := export.FormatFromPath(output); ok {
+		return f
+	}
+	return export.CSV
+}
+
+// writeExport writes tables to the output file, or stdout if it is empty.
+func writeExport(output string, format export.Format, tables []export.Table) {
+	var w io.Writer = os.Stdout
+	if output == "" && format.IsBinary() && ui.IsTerminal(os.Stdout) {
+		log.Fatalf("Refusing to write %s to a terminal; use -o FILE.", format)
+	}
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := export.Write(w, format, tables); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// buildExportTable loads, filters and sorts a table and picks its columns.
+func buildExportTable(tableName string, conditions []query.Condition, sortKey, columnList string) (export.Table, error) {
+	properties, err := catalog.Load(tableName)
+	if err != nil {
+		return export.Table{}, err
+	}
+	properties = query.Filter(properties, conditions)
+	if sortKey != "" {
+		if err := query.Sort(properties, sortKey); err != nil {
+			return export.Table{}, err
+		}
+	}
+
+	selected, err := exportColumns(properties, columnList)
+	if err != nil {
+		return export.Table{}, err
+	}
+	return export.Table{
+		Name:       catalog.TableName(catalog.TableFile(tableName)),
+		Properties: properties,
+		Columns:    selected,
+	}, nil
+}
+
+// exportColumns returns the columns named in a comma-separated list, or
+// every column with data in properties if the list is empty.
+func exportColumns(properties []models.SteelProperty, columnList string) ([]columns.ColumnInfo, error) {
+	allColumns := columns.GetAll()
+	if columnList == "" {
+		return columns.FilterAvailable(allColumns, properties), nil
+	}
+	var names []string
+	for _, name := range strings.Split(columnList, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !strings.EqualFold(name, "Section") {
+			names = append(names, name)
+		}
+	}
+	return columns.Select(append(allColumns, columns.GetCapacities()...), names)
+}
+
+// rowOutput holds the flags of commands that generate rows: they are printed
+// as a table, or exported like catalog tables.
+type rowOutput struct {
+	color   *string
+	format  *string
+	columns *string
+	output  string
+}
+
+func rowOutputFlags(flags *flag.FlagSet) *rowOutput {
+	o := &rowOutput{
+		color:   flags.String("color", "auto", "colour output: auto, always or never"),
+		format:  flags.String("format", "", "export format: "+strings.Join(export.Formats(), ", ")+" (default: print a table)"),
+		columns: flags.String("columns", "", "comma-separated columns to export (default: all columns with data)"),
+	}
+	flags.StringVar(&o.output, "output", "", "export to file instead of printing")
+	flags.StringVar(&o.output, "o", "", "shorthand for --output")
+	return o
+}
+
+// show prints or exports the rows under a title.
+func (o *rowOutput) show(title string, properties []models.SteelProperty) {
+	if *o.format != "" || o.output != "" || *o.columns != "" {
+		selected, err := exportColumns(properties, *o.columns)
+		if err != nil {
+			log.Fatal(err)
+		}
+		table := export.Table{Name: title, Properties: properties, Columns: selected}
+		writeExport(o.output, exportFormat(*o.format, o.output), []export.Table{table})
+		return
+	}
+	colorMode, err := ui.ParseColorMode(*o.color)
+	if err != nil {
+		log.Fatal(err)
+	}
+	viewer.PrintPropertiesOnce(ui.TerminalRenderer(colorMode.Enabled(os.Stdout)), title, properties)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+
+	"steel_tables/internal/analysis"
+	"steel_tables/internal/loads"
+)
+
+func runCalcFrame(args []string) {
+	flags := flag.NewFlagSet("calc frame", flag.ExitOnError)
+	loadCase := flags.String("case", "", "report only this load case")
+	combosFlag := flags.Bool("combos", false, "generate AS/NZS 1170.0 combinations and check the members")
+	combo := flags.String("combo", "", "report the full results of this combination")
+	occupancyFlag := flags.String("occupancy", "office", "occupancy for the Table 4.1 ψ factors")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc frame MODEL.json [--case NAME | --combos [--combo NAME] [--occupancy NAME]]")
+		flags.PrintDefaults()
+	}
+	positional := parseArgs(flags, args)
+	if len(positional) != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	frame, err := analysis.LoadFrame(positional[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	results, err := analysis.AnalyseFrame(frame)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	name := filepath.Base(positional[0])
+	if *combosFlag || *combo != "" {
+		occupancy, err := loads.ParseOccupancy(*occupancyFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		combos, err := loads.Generate(frame.LoadCases(), occupancy)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *combo != "" {
+			for _, c := range combos {
+				if c.Name == *combo {
+					res := analysis.CombineFrame(results, c)
+					res.LoadCase = fmt.Sprintf("%s (%s)", c.Name, c)
+					printFrameResult(os.Stdout, name, res)
+					return
+				}
+			}
+			log.Fatalf("combination '%s' not found", *combo)
+		}
+		printFrameCombinations(os.Stdout, name, results, combos)
+		return
+	}
+
+	printed := false
+	for _, res := range results {
+		if *loadCase != "" && res.LoadCase != *loadCase {
+			continue
+		}
+		if printed {
+			fmt.Println()
+		}
+		printFrameResult(os.Stdout, name, res)
+		printed = true
+	}
+	if !printed {
+		log.Fatalf("load case '%s' not found", *loadCase)
+	}
+}
+
+func printFrameResult(w io.Writer, name string, res analysis.FrameResult) {
+	r := newReport(w)
+	title := "Frame: " + name
+	if res.LoadCase != "" {
+		title += ", load case " + res.LoadCase
+	}
+	r.title(title)
+
+	r.section("Displacements")
+	rows := [][]string{}
+	for _, d := range res.Displacements {
+		rows = append(rows, []string{d.Node, fixed(d.DX, 2), fixed(d.DY, 2), fixed(d.Rotation*1000, 3)})
+	}
+	r.table([]string{"Node", "dx (mm)", "dy (mm)", "θ (mrad)"}, rows)
+
+	r.section("Reactions")
+	rows = rows[:0]
+	for _, re := range res.Reactions {
+		rows = append(rows, []string{re.Node, fixed(re.FX, 1), fixed(re.FY, 1), fixed(re.M, 1)})
+	}
+	r.table([]string{"Node", "Rx (kN)", "Ry (kN)", "M (kNm)"}, rows)
+
+	r.section("Member end actions (N tension +, M sagging +)")
+	rows = rows[:0]
+	for _, m := range res.Members {
+		s, e := m.Start(), m.End()
+		rows = append(rows, []string{m.Member.ID, m.Member.Section,
+			fmt.Sprintf("%.2f", m.Length), fmt.Sprintf("%.1f", m.Angle),
+			fixed(s.N, 1), fixed(s.V, 1), fixed(s.M, 1),
+			fixed(e.N, 1), fixed(e.V, 1), fixed(e.M, 1)})
+	}
+	r.table([]string{"Member", "Section", "L (m)", "Angle (°)", "N1 (kN)", "V1 (kN)", "M1 (kNm)", "N2 (kN)", "V2 (kN)", "M2 (kNm)"}, rows)
+
+	r.section("Member maxima")
+	rows = rows[:0]
+	for _, m := range res.Members {
+		peak := m.Max()
+		at := 0.0
+		for _, s := range m.Stations {
+			if s.M == peak.M {
+				at = s.X
+				break
+			}
+		}
+		rows = append(rows, []string{m.Member.ID, fixed(peak.N, 1), fixed(peak.V, 1),
+			fixed(peak.M, 1), fmt.Sprintf("%.2f", at)})
+	}
+	r.table([]string{"Member", "N (kN)", "V (kN)", "M (kNm)", "at (m)"}, rows)
+	r.flush()
+}
+
+// printFrameCombinations prints the reactions, member checks and
+// displacements of a frame under every load combination.
+func printFrameCombinations(w io.Writer, name string, results []analysis.FrameResult, combos []loads.Combination) {
+	r := newReport(w)
+	r.title("Frame: " + name + ", AS/NZS 1170.0 combinations")
+	printCombinations(r, combos)
+
+	var strength []analysis.FrameResult
+	r.section("Reactions")
+	rows := [][]string{}
+	for _, c := range combos {
+		res := analysis.CombineFrame(results, c)
+		if c.State == loads.Strength {
+			strength = append(strength, res)
+		}
+		for _, re := range res.Reactions {
+			rows = append(rows, []string{c.Name, re.Node, fixed(re.FX, 1), fixed(re.FY, 1), fixed(re.M, 1)})
+		}
+	}
+	r.table([]string{"Combination", "Node", "Rx (kN)", "Ry (kN)", "M (kNm)"}, rows)
+
+	designs, err := analysis.DesignMembers(strength)
+	if err != nil {
+		log.Fatal(err)
+	}
+	r.section("Member checks, worst strength combination (AS 4100 Section 8, βm = -1)")
+	rows = rows[:0]
+	for _, d := range designs {
+		rows = append(rows, []string{d.Member.ID, d.Member.Section, d.Combination,
+			fixed(d.N, 1), fixed(d.M, 1), fixed(d.V, 1),
+			fmt.Sprintf("%.3f", d.Ratio), d.Description, passFail(d.Passes())})
+	}
+	r.table([]string{"Member", "Section", "Combination", "N* (kN)", "M* (kNm)", "V* (kN)", "Ratio", "Governing", ""}, rows)
+
+	r.section("Displacements, serviceability combinations")
+	rows = rows[:0]
+	for _, c := range combos {
+		if c.State != loads.Serviceability {
+			continue
+		}
+		res := analysis.CombineFrame(results, c)
+		var dx, dy analysis.Displacement
+		for _, d := range res.Displacements {
+			if math.Abs(d.DX) > math.Abs(dx.DX) {
+				dx = d
+			}
+			if math.Abs(d.DY) > math.Abs(dy.DY) {
+				dy = d
+			}
+		}
+		rows = append(rows, []string{c.Name, c.String(), fixed(dx.DX, 1), dx.Node, fixed(dy.DY, 1), dy.Node})
+	}
+	r.table([]string{"Combination", "", "max dx (mm)", "Node", "max dy (mm)", "Node"}, rows)
+	r.flush()
+}
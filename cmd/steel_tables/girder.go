@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"steel_tables/internal/girder"
+	"steel_tables/internal/models"
+)
+
+func runGirder(args []string) {
+	flags := flag.NewFlagSet("girder", flag.ExitOnError)
+	grade := flags.Int("grade", girder.DefaultGrade, "plate grade: 300, 350 or 400")
+	top := flags.String("top", "", "top flange plate WIDTHxTHICKNESS in mm")
+	web := flags.String("web", "", "web plate HEIGHTxTHICKNESS in mm")
+	bottom := flags.String("bottom", "", "bottom flange plate WIDTHxTHICKNESS in mm (default --top)")
+	out := rowOutputFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables girder TOP-WEB[-BOTTOM]PG [...] [--grade N] [--format F | -o FILE]")
+		fmt.Fprintln(flags.Output(), "       steel_tables girder --top BxT --web HxT [--bottom BxT] [--grade N]")
+		fmt.Fprintln(flags.Output(), "Plates are WIDTHxTHICKNESS in mm, e.g. 500x32-1200x16-400x25PG.")
+		flags.PrintDefaults()
+	}
+	designations := parseArgs(flags, args)
+	if *web != "" {
+		if *top == "" {
+			log.Fatal("--web needs --top")
+		}
+		spec := *top + "-" + *web
+		if *bottom != "" {
+			spec += "-" + *bottom
+		}
+		designations = append(designations, spec+"PG")
+	}
+	if len(designations) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var properties []models.SteelProperty
+	for _, d := range designations {
+		if !girder.IsDesignation(d) {
+			d += "PG"
+		}
+		g, err := girder.Parse(d, *grade)
+		if err != nil {
+			log.Fatal(err)
+		}
+		p, err := g.Property()
+		if err != nil {
+			log.Fatal(err)
+		}
+		properties = append(properties, p)
+	}
+
+	out.show("Plate girders", properties)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"steel_tables/internal/catalog"
+	"steel_tables/internal/hollow"
+	"steel_tables/internal/models"
+)
+
+func runHollow(args []string) {
+	flags := flag.NewFlagSet("hollow", flag.ExitOnError)
+	grade := flags.Int("grade", hollow.DefaultGrade, "grade: 250, 350 or 450")
+	radius := flags.Float64("radius", 0, "outside corner radius in mm (default 2t for t ≤ 3 mm, else 2.5t)")
+	save := flags.Bool("save", false, "save the sections to the "+catalog.UserTable+" table")
+	out := rowOutputFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables hollow SECTION [SECTION...] [--grade N] [--radius MM] [--save] [--format F | -o FILE]")
+		fmt.Fprintln(flags.Output(), "Sections are e.g. \"250 x 150 x 6 RHS\", \"89 x 3.5 SHS\" or \"114.3 x 4.5 CHS\".")
+		flags.PrintDefaults()
+	}
+	designations := parseArgs(flags, args)
+	if len(designations) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var properties []models.SteelProperty
+	for _, d := range designations {
+		s, err := hollow.Parse(d, *grade)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s.Radius = *radius
+		p, err := s.Property()
+		if err != nil {
+			log.Fatal(err)
+		}
+		properties = append(properties, p)
+	}
+
+	if *save {
+		if err := catalog.SaveUser(properties...); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Fprintf(os.Stderr, "Saved %d section(s) to the %s table.\n", len(properties), catalog.UserTable)
+	}
+	out.show("Hollow sections", properties)
+}
@@ -2,30 +2,75 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"strings"
 
-	"steel_tables/internal/config"
+	"steel_tables/internal/catalog"
 	"steel_tables/internal/ui"
 	"steel_tables/internal/viewer"
 )
 
 func main() {
-	fmt.Print(ui.Bg + ui.Clear)
-	defer fmt.Print(ui.Reset)
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			runExport(os.Args[2:])
+			return
+		case "calc":
+			runCalc(os.Args[2:])
+			return
+		case "select":
+			runSelect(os.Args[2:])
+			return
+		case "girder":
+			runGirder(os.Args[2:])
+			return
+		case "hollow":
+			runHollow(os.Args[2:])
+			return
+		case "cellular":
+			runCellular(os.Args[2:])
+			return
+		}
+	}
+
+	flags := flag.NewFlagSet("steel_tables", flag.ExitOnError)
+	colorFlag := flags.String("color", "auto", "colour output: auto, always or never")
+	args := parseArgs(flags, os.Args[1:])
+
+	colorMode, err := ui.ParseColorMode(*colorFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	if len(os.Args) < 2 {
+	if len(args) < 1 {
 		runInteractiveMode()
 	} else {
-		runCLIMode(os.Args[1])
+		runCLIMode(args[0], ui.TerminalRenderer(colorMode.Enabled(os.Stdout)))
 	}
+}
 
-	fmt.Print(ui.Clear)
+// parseArgs parses flags that may appear before or after positional
+// arguments and returns the positional arguments in order.
+func parseArgs(flags *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		flags.Parse(args)
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
 }
 
 func runInteractiveMode() {
+	fmt.Print(ui.Bg + ui.Clear)
+	defer fmt.Print(ui.Clear + ui.Reset)
+
 	initialState, err := ui.GetTerminalState()
 	if err != nil {
 		log.Fatalf("Fatal: Could not get terminal state: %v", err)
@@ -45,7 +90,7 @@ func runInteractiveMode() {
 			continue
 		}
 
-		returnToMenu := viewer.DisplayTable(selectedFile)
+		returnToMenu := viewer.DisplayTable(catalog.TableName(selectedFile))
 		ui.RestoreTerminal(initialState)
 
 		if !returnToMenu {
@@ -54,18 +99,10 @@ func runInteractiveMode() {
 	}
 }
 
-func runCLIMode(tableName string) {
-	filename := strings.ToUpper(tableName)
-	if !strings.HasSuffix(filename, "_PROPS") {
-		filename += "_PROPS"
-	}
-	if !strings.HasSuffix(filename, ".json") {
-		filename += ".json"
-	}
-
-	if !config.FileExists(filename) {
+func runCLIMode(tableName string, r *ui.Renderer) {
+	if !catalog.Exists(tableName) {
 		log.Fatalf("Table '%s' not found.", tableName)
 	}
 
-	viewer.PrintTableOnce(filename)
+	viewer.PrintTableOnce(r, tableName)
 }
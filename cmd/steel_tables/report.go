@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// report prints calculation results as aligned label/value/unit lines.
+type report struct {
+	tw *tabwriter.Writer
+}
+
+func newReport(w io.Writer) *report {
+	return &report{tw: tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)}
+}
+
+// title prints a heading followed by an underline.
+func (r *report) title(text string) {
+	r.tw.Flush()
+	fmt.Fprintf(r.tw, "%s\n%s\n", text, strings.Repeat("=", len([]rune(text))))
+}
+
+// section starts a titled group of lines.
+func (r *report) section(text string) {
+	r.tw.Flush()
+	fmt.Fprintf(r.tw, "\n%s\n", text)
+}
+
+// line prints a labelled value with its unit and an optional note.
+func (r *report) line(label, value, unit, note string) {
+	fmt.Fprintf(r.tw, "  %s\t%s\t%s\t%s\n", label, value, unit, note)
+}
+
+// text prints a free-form line.
+func (r *report) text(s string) {
+	r.tw.Flush()
+	fmt.Fprintf(r.tw, "%s\n", s)
+}
+
+// table prints a header row and rows as aligned columns.
+func (r *report) table(headers []string, rows [][]string) {
+	r.tw.Flush()
+	fmt.Fprintf(r.tw, "  %s\t\n", strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintf(r.tw, "  %s\t\n", strings.Join(row, "\t"))
+	}
+	r.tw.Flush()
+}
+
+func (r *report) flush() {
+	r.tw.Flush()
+}
+
+// num formats a value with the given number of decimals, or "-" if it is zero.
+func num(value float64, decimals int) string {
+	if value == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.*f", decimals, value)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"steel_tables/internal/selector"
+)
+
+func runSelect(args []string) {
+	flags := flag.NewFlagSet("select", flag.ExitOnError)
+	var req selector.Requirements
+	flags.Float64Var(&req.M, "m", 0, "design moment M* in kNm")
+	flags.Float64Var(&req.V, "v", 0, "design shear V* in kN")
+	flags.Float64Var(&req.N, "n", 0, "design axial force N* in kN (compression positive)")
+	flags.Float64Var(&req.Le, "le", 0, "effective length in m for member buckling")
+	flags.Float64Var(&req.AlphaM, "am", 1, "moment modification factor αm")
+	flags.Float64Var(&req.Span, "span", 0, "simply supported span in m for the deflection check")
+	flags.Float64Var(&req.ServiceLoad, "w", 0, "serviceability UDL in kN/m for the deflection check")
+	flags.Float64Var(&req.DeflectionLimit, "limit", 250, "deflection limit as span/limit")
+	families := flags.String("families", "UB,UC,WB,WC,PFC,RHS,SHS,CHS", "comma-separated section families to search")
+	gradeList := flags.String("grades", "", "comma-separated grades to search (default: all)")
+	top := flags.Int("top", 10, "number of candidates to list")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables select --m kNm [--v kN] [--n kN] [--le M] [--span M --w kN/m] [flags]")
+		flags.PrintDefaults()
+	}
+	if positional := parseArgs(flags, args); len(positional) > 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	if err := req.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	grades, err := selector.ParseGrades(*gradeList)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tables, err := selector.TablesFor(strings.Split(*families, ","))
+	if err != nil {
+		log.Fatal(err)
+	}
+	candidates, err := selector.Search(tables, grades, req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(candidates) == 0 {
+		fmt.Println("No section in the selected families meets the requirements.")
+		return
+	}
+	if len(candidates) > *top {
+		candidates = candidates[:*top]
+	}
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("Lightest sections: %s", strings.Join(tables, ", ")))
+	headers := []string{"#", "Section", "Table", "Weight (kg/m)"}
+	for _, u := range candidates[0].Checks {
+		headers = append(headers, u.Name)
+	}
+	headers = append(headers, "Governing")
+	rows := make([][]string, len(candidates))
+	for i, c := range candidates {
+		row := []string{fmt.Sprintf("%d", i+1), c.Property.Section, c.Table, fmt.Sprintf("%.1f", c.Property.Weight)}
+		for _, u := range c.Checks {
+			row = append(row, fmt.Sprintf("%.3f", u.Ratio))
+		}
+		row = append(row, c.Governing.Label())
+		rows[i] = row
+	}
+	r.text("")
+	r.table(headers, rows)
+	r.flush()
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/analysis"
+	"steel_tables/internal/calc"
+)
+
+func runCalcTorsion(args []string) {
+	flags := flag.NewFlagSet("calc torsion", flag.ExitOnError)
+	grade := sectionFlags(flags)
+	var torques, loads pointLoads
+	length := flags.Float64("length", 0, "member length in m")
+	ends := flags.String("ends", "pinned,pinned", "end restraints: pinned (warping free), fixed (warping prevented) or free")
+	flags.Var(&torques, "t", "concentrated torque T@A in kNm at m from the left end (repeatable)")
+	distributed := flags.Float64("m", 0, "uniform torque over the length in kNm/m")
+	flags.Var(&loads, "p", "transverse point load P@A in kN applied at --ecc from the shear centre (repeatable)")
+	udl := flags.Float64("w", 0, "transverse UDL in kN/m applied at --ecc from the shear centre")
+	ecc := flags.String("ecc", "", "eccentricity of --p and --w from the shear centre in mm (default: the web centreline of a channel)")
+	points := flags.Int("points", 11, "number of stations to tabulate")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: steel_tables calc torsion SECTION --length M [--ends pinned,pinned] [--t kNm@M]... [--m kNm/m] [--p kN@M]... [--w kN/m] [--ecc MM]")
+		flags.PrintDefaults()
+	}
+	match := findSection(flags, args, grade)
+	p := match.Property
+
+	section, err := analysis.TorsionSectionOf(p)
+	if err != nil {
+		log.Fatal(err)
+	}
+	m := analysis.TorsionMember{Length: *length, J: section.J, Iw: section.Iw, Distributed: *distributed}
+	names := strings.Split(*ends, ",")
+	if len(names) != 2 {
+		log.Fatalf("--ends needs two restraints, e.g. fixed,free")
+	}
+	for i, name := range names {
+		if m.Ends[i], err = analysis.ParseTorsionEnd(name); err != nil {
+			log.Fatal(err)
+		}
+	}
+	for _, t := range torques {
+		m.Torques = append(m.Torques, analysis.PointTorque{T: t.P, At: t.A})
+	}
+	eccentric := len(loads) > 0 || *udl != 0
+	var e float64
+	if eccentric {
+		switch {
+		case *ecc != "":
+			if e, err = strconv.ParseFloat(*ecc, 64); err != nil {
+				log.Fatalf("invalid --ecc '%s'", *ecc)
+			}
+		case section.Offset == 0:
+			log.Fatal("--p and --w need --ecc, the load's eccentricity from the shear centre")
+		default:
+			e = section.Offset
+		}
+		for _, pl := range loads {
+			m.Torques = append(m.Torques, analysis.PointTorque{T: pl.P * e / 1000, At: pl.A})
+		}
+		m.Distributed += *udl * e / 1000
+	}
+
+	res, err := analysis.AnalyseTorsion(m)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fy := calc.Yield(p)
+
+	r := newReport(os.Stdout)
+	r.title(fmt.Sprintf("%s  [%s]", p.Section, match.Table))
+	r.section(fmt.Sprintf("Torsion, L = %.2f m, ends %s–%s", m.Length, m.Ends[0], m.Ends[1]))
+	r.line("J", num(section.J, 1), "10³mm⁴", "")
+	r.line("Iw", num(section.Iw, 1), "10⁹mm⁶", "")
+	r.line("a", fmt.Sprintf("%.3f", res.A), "m", "√(EIw/GJ)")
+	r.line("Wn", num(section.Wn, 0), "mm²", "normalised warping function at the flange")
+	r.line("Sw", fmt.Sprintf("%.3g", section.Sw), "mm⁴", "warping statical moment in the flange")
+	if section.Offset > 0 {
+		r.line("e0", fmt.Sprintf("%.1f", section.Offset), "mm", "shear centre from the web centreline")
+	}
+	if eccentric {
+		r.line("e", fmt.Sprintf("%.1f", e), "mm", "eccentricity of the transverse loads")
+	}
+	for _, t := range m.Torques {
+		r.line("T", fmt.Sprintf("%.2f", t.T), "kNm", fmt.Sprintf("at %.2f m", t.At))
+	}
+	if m.Distributed != 0 {
+		r.line("m", fmt.Sprintf("%.2f", m.Distributed), "kNm/m", "uniform")
+	}
+
+	// Tabulate the stations nearest evenly spaced points, then the maxima.
+	var rows [][]string
+	for k := 0; k < *points; k++ {
+		at := m.Length * float64(k) / float64(max(*points-1, 1))
+		st := res.Stations[0]
+		for _, s := range res.Stations {
+			if math.Abs(s.X-at) < math.Abs(st.X-at) {
+				st = s
+			}
+		}
+		stress := section.Stresses(st, fy)
+		rows = append(rows, []string{
+			signed(st.X, 2), signed(st.Twist, 4),
+			signed(st.Total, 2), signed(st.StVenant, 2), signed(st.Warping, 2),
+			signed(st.Bimoment, 3),
+			signed(stress.Warping, 1), signed(stress.StVenantShear, 1), signed(stress.WarpingShear, 1),
+		})
+	}
+	r.section("Distribution along the member")
+	r.table([]string{"x (m)", "φ (rad)", "T (kNm)", "Tsv (kNm)", "Tw (kNm)", "B (kNm²)", "σw (MPa)", "τsv (MPa)", "τw (MPa)"}, rows)
+
+	var twist, bimoment, shear analysis.TorsionStation
+	var maxShear float64
+	for _, s := range res.Stations {
+		if math.Abs(s.Twist) > math.Abs(twist.Twist) {
+			twist = s
+		}
+		if math.Abs(s.Bimoment) > math.Abs(bimoment.Bimoment) {
+			bimoment = s
+		}
+		if c := section.Stresses(s, fy).CombinedShear; c > maxShear {
+			shear, maxShear = s, c
+		}
+	}
+	normal := section.Stresses(bimoment, fy)
+	combined := section.Stresses(shear, fy)
+	r.section("Maxima")
+	r.line("φ", fmt.Sprintf("%.4f", twist.Twist), "rad", fmt.Sprintf("%.2f° at %.2f m", twist.Twist*180/math.Pi, twist.X))
+	r.line("B", fmt.Sprintf("%.3f", bimoment.Bimoment), "kNm²", fmt.Sprintf("at %.2f m", bimoment.X))
+	r.line("σw", fmt.Sprintf("%.1f", normal.Warping), "MPa", fmt.Sprintf("%.2f fy, B Wn/Iw", normal.NormalToYield))
+	r.line("τsv + τw", fmt.Sprintf("%.1f", combined.CombinedShear), "MPa", fmt.Sprintf("%.2f × 0.6 fy at %.2f m", combined.ShearToYield, shear.X))
+	r.line("fy", num(fy, 0), "MPa", "")
+	r.text("\n  Add σw to the bending stresses at the flange tips when checking combined actions.")
+	r.flush()
+}
+
+// signed formats a value that may be negative without printing "-0.00" for
+// values that round to zero.
+func signed(value float64, decimals int) string {
+	scale := math.Pow(10, float64(decimals))
+	if math.Round(value*scale) == 0 {
+		value = 0
+	}
+	return fmt.Sprintf("%.*f", decimals, value)
+}
@@ -0,0 +1,526 @@
+// Package analysis implements linear elastic structural analysis of beams
+// and frames built from catalog sections.
+//
+// Inputs are in kN, kN/m and m with section properties in the catalog's table
+// units. Internally everything is converted to N and mm; results are reported
+// in kN, kNm and mm.
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/calc"
+)
+
+// Support is the restraint at a support of a continuous beam.
+type Support int
+
+const (
+	Pinned Support = iota
+	Roller
+	Fixed
+	Free
+)
+
+// ParseSupport converts a support name to a Support.
+func ParseSupport(value string) (Support, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "pin", "pinned":
+		return Pinned, nil
+	case "roller":
+		return Roller, nil
+	case "fixed", "fix":
+		return Fixed, nil
+	case "free", "none":
+		return Free, nil
+	}
+	return Pinned, fmt.Errorf("invalid support '%s' (want pin, roller, fixed or free)", value)
+}
+
+func (s Support) String() string {
+	switch s {
+	case Roller:
+		return "roller"
+	case Fixed:
+		return "fixed"
+	case Free:
+		return "free"
+	default:
+		return "pin"
+	}
+}
+
+// LoadKind distinguishes point loads from distributed loads.
+type LoadKind int
+
+const (
+	PointLoad LoadKind = iota
+	DistributedLoad
+)
+
+// BeamLoad is a downward load on one span. Positions are measured from the
+// left end of the span; a distributed load with End = 0 covers the whole span.
+type BeamLoad struct {
+	Span    int // Span index, from 0
+	Kind    LoadKind
+	Value   float64 // kN for point loads, kN/m for distributed loads
+	Start   float64 // Point load position or start of a partial UDL (m)
+	End     float64 // End of a partial UDL (m)
+	Pattern bool    // Applied span by span to find the worst pattern
+
+	LoadCase string // Load case name for combinations
+}
+
+// extent returns the loaded length of the load on a span of length l (m).
+func (ld BeamLoad) extent(l float64) (float64, float64) {
+	if ld.Kind == PointLoad {
+		return ld.Start, ld.Start
+	}
+	if ld.End == 0 && ld.Start == 0 {
+		return 0, l
+	}
+	return ld.Start, ld.End
+}
+
+// Beam is a continuous beam of constant section.
+type Beam struct {
+	Spans    []float64 // Span lengths (m)
+	Supports []Support // One per span end, len(Spans)+1
+	I        float64   // Second moment of area about the bending axis (10⁶mm⁴)
+	Loads    []BeamLoad
+}
+
+// Validate checks the beam geometry and loads.
+func (b Beam) Validate() error {
+	if len(b.Spans) == 0 {
+		return fmt.Errorf("beam needs at least one span")
+	}
+	if len(b.Supports) != len(b.Spans)+1 {
+		return fmt.Errorf("%d spans need %d supports, got %d", len(b.Spans), len(b.Spans)+1, len(b.Supports))
+	}
+	for i, l := range b.Spans {
+		if l <= 0 {
+			return fmt.Errorf("span %d length must be positive", i+1)
+		}
+	}
+	if b.I <= 0 {
+		return fmt.Errorf("second moment of area must be positive")
+	}
+	for _, ld := range b.Loads {
+		if ld.Span < 0 || ld.Span >= len(b.Spans) {
+			return fmt.Errorf("load on span %d, but the beam has %d spans", ld.Span+1, len(b.Spans))
+		}
+		l := b.Spans[ld.Span]
+		a, e := ld.extent(l)
+		if a < 0 || e > l+1e-9 || e < a {
+			return fmt.Errorf("load on span %d at %.2f–%.2f m is outside the %.2f m span", ld.Span+1, a, e, l)
+		}
+		if ld.Kind == DistributedLoad && e == a {
+			return fmt.Errorf("partial UDL on span %d has zero length", ld.Span+1)
+		}
+	}
+	return nil
+}
+
+// Range is the minimum and maximum of a result over the load patterns.
+type Range struct {
+	Min, Max float64
+}
+
+// Abs returns the largest magnitude in the range.
+func (r Range) Abs() float64 {
+	return math.Max(math.Abs(r.Min), math.Abs(r.Max))
+}
+
+func (r Range) merge(o Range) Range {
+	return Range{math.Min(r.Min, o.Min), math.Max(r.Max, o.Max)}
+}
+
+// Station is the envelope of results at a point along the beam. Shear is
+// positive when the left-hand part is pushed up, moment is positive sagging
+// and deflection is positive downward.
+type Station struct {
+	X          float64 // Distance from the left end of the beam (m)
+	Span       int
+	Shear      Range // kN
+	Moment     Range // kNm
+	Deflection Range // mm
+}
+
+// Reaction is the envelope of the support reactions, positive upward and
+// anticlockwise.
+type Reaction struct {
+	Support int
+	X       float64
+	Force   Range // kN
+	Moment  Range // kNm, fixed supports only
+}
+
+// BeamResult holds the analysis of a continuous beam. Stations come in
+// pairs at each node, either side of it, so shear steps at point loads and
+// supports are kept.
+type BeamResult struct {
+	Beam      Beam
+	Stations  []Station
+	Reactions []Reaction
+}
+
+// divisionsPerSpan is the number of elements each span is split into before
+// adding nodes at loads.
+const divisionsPerSpan = 40
+
+// mesh holds the nodes of the discretised beam.
+type mesh struct {
+	x       []float64 // node positions from the left end (mm)
+	span    []int     // span of the element starting at each node
+	support []int     // node index of each support
+}
+
+func buildMesh(b Beam) mesh {
+	var m mesh
+	offset := 0.0
+	for s, l := range b.Spans {
+		points := []float64{}
+		for k := 0; k < divisionsPerSpan; k++ {
+			points = append(points, l*float64(k)/divisionsPerSpan)
+		}
+		for _, ld := range b.Loads {
+			if ld.Span != s {
+				continue
+			}
+			a, e := ld.extent(l)
+			points = append(points, a, e)
+		}
+		sort.Float64s(points)
+		m.support = append(m.support, len(m.x))
+		last := -1.0
+		for _, p := range points {
+			if p >= l-1e-9 || (last >= 0 && p-last < 1e-9) {
+				continue
+			}
+			m.x = append(m.x, (offset+p)*1000)
+			m.span = append(m.span, s)
+			last = p
+		}
+		offset += l
+	}
+	m.support = append(m.support, len(m.x))
+	m.x = append(m.x, offset*1000)
+	m.span = append(m.span, len(b.Spans)-1)
+	return m
+}
+
+// beamStiffness returns the 4x4 stiffness matrix of a beam element for the
+// dofs (v1, θ1, v2, θ2).
+func beamStiffness(ei, l float64) [][]float64 {
+	c := ei / (l * l * l)
+	return [][]float64{
+		{12 * c, 6 * l * c, -12 * c, 6 * l * c},
+		{6 * l * c, 4 * l * l * c, -6 * l * c, 2 * l * l * c},
+		{-12 * c, -6 * l * c, 12 * c, -6 * l * c},
+		{6 * l * c, 2 * l * l * c, -6 * l * c, 4 * l * l * c},
+	}
+}
+
+// AnalyseBeam solves a continuous beam. Loads marked Pattern are applied
+// span by span and added to the permanent loads wherever they make a result
+// worse, giving the envelope for every combination of loaded spans.
+func AnalyseBeam(b Beam) (*BeamResult, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	m := buildMesh(b)
+	ei := calc.E * b.I * 1e6
+
+	// Case 0 is the permanent load; case s+1 is the pattern load on span s.
+	cases := len(b.Spans) + 1
+	caseOf := func(ld BeamLoad) int {
+		if ld.Pattern {
+			return ld.Span + 1
+		}
+		return 0
+	}
+
+	nodes := len(m.x)
+	sys := newSystem(2*nodes, cases, 3)
+	// udl[c][e] is the uniform load intensity (N/mm, downward) on element e.
+	udl := make([][]float64, cases)
+	for c := range udl {
+		udl[c] = make([]float64, nodes-1)
+	}
+	spanStart := make([]float64, len(b.Spans))
+	for s := range b.Spans {
+		spanStart[s] = m.x[m.support[s]]
+	}
+
+	for e := 0; e < nodes-1; e++ {
+		l := m.x[e+1] - m.x[e]
+		sys.add(beamStiffness(ei, l), []int{2 * e, 2*e + 1, 2*e + 2, 2*e + 3})
+	}
+	for _, ld := range b.Loads {
+		c := caseOf(ld)
+		a, end := ld.extent(b.Spans[ld.Span])
+		a = spanStart[ld.Span] + a*1000
+		end = spanStart[ld.Span] + end*1000
+		switch ld.Kind {
+		case PointLoad:
+			sys.loads[c][2*nearestNode(m.x, a)] -= ld.Value * 1000
+		case DistributedLoad:
+			for e := 0; e < nodes-1; e++ {
+				mid := (m.x[e] + m.x[e+1]) / 2
+				if mid > a && mid < end {
+					udl[c][e] += ld.Value
+				}
+			}
+		}
+	}
+	for c := range udl {
+		for e, w := range udl[c] {
+			if w == 0 {
+				continue
+			}
+			l := m.x[e+1] - m.x[e]
+			sys.loads[c][2*e] -= w * l / 2
+			sys.loads[c][2*e+1] -= w * l * l / 12
+			sys.loads[c][2*e+2] -= w * l / 2
+			sys.loads[c][2*e+3] += w * l * l / 12
+		}
+	}
+	for i, s := range b.Supports {
+		n := m.support[i]
+		switch s {
+		case Pinned, Roller:
+			sys.restrained[2*n] = true
+		case Fixed:
+			sys.restrained[2*n] = true
+			sys.restrained[2*n+1] = true
+		}
+	}
+
+	d, err := sys.solve()
+	if err != nil {
+		return nil, err
+	}
+
+	// Results per case at both ends of every element.
+	type value struct{ v, m, y float64 }
+	perCase := make([][]value, cases)
+	for c := 0; c < cases; c++ {
+		for e := 0; e < nodes-1; e++ {
+			l := m.x[e+1] - m.x[e]
+			ke := beamStiffness(ei, l)
+			de := d[c][2*e : 2*e+4]
+			w := udl[c][e]
+			fixed := []float64{-w * l / 2, -w * l * l / 12, -w * l / 2, w * l * l / 12}
+			f := make([]float64, 4)
+			for i := range f {
+				for j := range de {
+					f[i] += ke[i][j] * de[j]
+				}
+				f[i] -= fixed[i]
+			}
+			perCase[c] = append(perCase[c],
+				value{f[0], -f[1], -de[0]},
+				value{-f[2], f[3], -de[2]})
+		}
+	}
+
+	res := &BeamResult{Beam: b}
+	for k := range perCase[0] {
+		e := k / 2
+		node := e + k%2
+		st := Station{X: m.x[node] / 1000, Span: m.span[e]}
+		base := perCase[0][k]
+		st.Shear = Range{base.v, base.v}
+		st.Moment = Range{base.m, base.m}
+		st.Deflection = Range{base.y, base.y}
+		for c := 1; c < cases; c++ {
+			st.Shear = addPattern(st.Shear, perCase[c][k].v)
+			st.Moment = addPattern(st.Moment, perCase[c][k].m)
+			st.Deflection = addPattern(st.Deflection, perCase[c][k].y)
+		}
+		st.Shear = scaleRange(st.Shear, 1e-3)
+		st.Moment = scaleRange(st.Moment, 1e-6)
+		res.Stations = append(res.Stations, st)
+	}
+
+	for i, s := range b.Supports {
+		if s == Free {
+			continue
+		}
+		n := m.support[i]
+		r := Reaction{Support: i, X: m.x[n] / 1000}
+		for c := 0; c < cases; c++ {
+			f := sys.reaction(2*n, c, d[c])
+			mo := 0.0
+			if s == Fixed {
+				mo = sys.reaction(2*n+1, c, d[c])
+			}
+			if c == 0 {
+				r.Force = Range{f, f}
+				r.Moment = Range{mo, mo}
+				continue
+			}
+			r.Force = addPattern(r.Force, f)
+			r.Moment = addPattern(r.Moment, mo)
+		}
+		r.Force = scaleRange(r.Force, 1e-3)
+		r.Moment = scaleRange(r.Moment, 1e-6)
+		res.Reactions = append(res.Reactions, r)
+	}
+	return res, nil
+}
+
+// addPattern adds a pattern load's result to the envelope where it
+// increases the maximum or decreases the minimum.
+func addPattern(r Range, v float64) Range {
+	if v > 0 {
+		r.Max += v
+	} else {
+		r.Min += v
+	}
+	return r
+}
+
+func scaleRange(r Range, f float64) Range {
+	return Range{r.Min * f, r.Max * f}
+}
+
+func nearestNode(x []float64, at float64) int {
+	i := sort.SearchFloat64s(x, at)
+	if i == len(x) || (i > 0 && at-x[i-1] < x[i]-at) {
+		return i - 1
+	}
+	return i
+}
+
+// SpanExtremes summarises the envelope on one span.
+type SpanExtremes struct {
+	Sagging, SaggingAt    float64 // Largest sagging moment (kNm) and position (m)
+	Hogging, HoggingAt    float64 // Largest hogging moment (kNm, negative) and position (m)
+	Shear, ShearAt        float64 // Largest shear magnitude (kN) and position (m)
+	Deflection, DeflectAt float64 // Largest deflection magnitude (mm) and position (m)
+}
+
+// Extremes returns the envelope extremes on span s.
+func (r *BeamResult) Extremes(s int) SpanExtremes {
+	var x SpanExtremes
+	for _, st := range r.Stations {
+		if st.Span != s {
+			continue
+		}
+		if st.Moment.Max > x.Sagging {
+			x.Sagging, x.SaggingAt = st.Moment.Max, st.X
+		}
+		if st.Moment.Min < x.Hogging {
+			x.Hogging, x.HoggingAt = st.Moment.Min, st.X
+		}
+		if st.Shear.Abs() > x.Shear {
+			x.Shear, x.ShearAt = st.Shear.Abs(), st.X
+		}
+		if st.Deflection.Abs() > x.Deflection {
+			x.Deflection, x.DeflectAt = st.Deflection.Abs(), st.X
+		}
+	}
+	return x
+}
+
+// At returns the envelope at x (m), merging the stations either side of a
+// node.
+func (r *BeamResult) At(x float64) Station {
+	var st Station
+	found := false
+	for _, s := range r.Stations {
+		if math.Abs(s.X-x) > 1e-6 {
+			continue
+		}
+		if !found {
+			st, found = s, true
+			continue
+		}
+		st.Shear = st.Shear.merge(s.Shear)
+		st.Moment = st.Moment.merge(s.Moment)
+		st.Deflection = st.Deflection.merge(s.Deflection)
+	}
+	return st
+}
+
+// Length returns the total length of the beam (m).
+func (b Beam) Length() float64 {
+	total := 0.0
+	for _, l := range b.Spans {
+		total += l
+	}
+	return total
+}
+
+// ParseBeamLoad parses a load specification for a beam with the given number
+// of spans. Spans are numbered from 1; a UDL without a span applies to every
+// span:
+//
+//	udl:W           W kN/m on every span
+//	udl:W@S         W kN/m on span S
+//	udl:W@S:A-B     W kN/m on span S from A to B m
+//	point:P@S:A     P kN on span S at A m
+func ParseBeamLoad(spec string, spans int, pattern bool) ([]BeamLoad, error) {
+	kind, rest, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid load '%s' (want udl:W[@S[:A-B]] or point:P@S:A)", spec)
+	}
+	valueText, where, hasSpan := strings.Cut(rest, "@")
+	value, err := strconv.ParseFloat(valueText, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid load value in '%s'", spec)
+	}
+	spanText, position, hasPosition := strings.Cut(where, ":")
+
+	span := -1
+	if hasSpan {
+		n, err := strconv.Atoi(spanText)
+		if err != nil || n < 1 || n > spans {
+			return nil, fmt.Errorf("invalid span in '%s' (want 1 to %d)", spec, spans)
+		}
+		span = n - 1
+	}
+
+	switch strings.ToLower(kind) {
+	case "udl", "w":
+		ld := BeamLoad{Kind: DistributedLoad, Value: value, Pattern: pattern}
+		if hasPosition {
+			a, b, ok := strings.Cut(position, "-")
+			start, err1 := strconv.ParseFloat(a, 64)
+			end, err2 := strconv.ParseFloat(b, 64)
+			if !ok || err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("invalid extent in '%s' (want A-B in m)", spec)
+			}
+			ld.Start, ld.End = start, end
+		}
+		if span >= 0 {
+			ld.Span = span
+			return []BeamLoad{ld}, nil
+		}
+		if hasPosition {
+			return nil, fmt.Errorf("partial UDL '%s' needs a span", spec)
+		}
+		loads := make([]BeamLoad, spans)
+		for s := range loads {
+			ld.Span = s
+			loads[s] = ld
+		}
+		return loads, nil
+	case "point", "p":
+		if span < 0 || !hasPosition {
+			return nil, fmt.Errorf("point load '%s' needs a span and position", spec)
+		}
+		a, err := strconv.ParseFloat(position, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid position in '%s'", spec)
+		}
+		return []BeamLoad{{Span: span, Kind: PointLoad, Value: value, Start: a, Pattern: pattern}}, nil
+	}
+	return nil, fmt.Errorf("invalid load type '%s' (want udl or point)", kind)
+}
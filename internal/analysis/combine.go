@@ -0,0 +1,222 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+
+	"steel_tables/internal/calc"
+	"steel_tables/internal/loads"
+)
+
+// CombineFrame returns the frame result for a load combination by adding the
+// load case results with the combination's factors. Cases not in the
+// combination are ignored.
+func CombineFrame(results []FrameResult, c loads.Combination) FrameResult {
+	var out FrameResult
+	for _, res := range results {
+		f := c.Factor(res.LoadCase)
+		if out.Displacements == nil {
+			out = zeroFrame(res)
+			out.LoadCase = c.Name
+		}
+		if f == 0 {
+			continue
+		}
+		for i, d := range res.Displacements {
+			o := &out.Displacements[i]
+			o.DX += f * d.DX
+			o.DY += f * d.DY
+			o.Rotation += f * d.Rotation
+		}
+		for i, r := range res.Reactions {
+			o := &out.Reactions[i]
+			o.FX += f * r.FX
+			o.FY += f * r.FY
+			o.M += f * r.M
+		}
+		for i, m := range res.Members {
+			for j, s := range m.Stations {
+				o := &out.Members[i].Stations[j]
+				o.N += f * s.N
+				o.V += f * s.V
+				o.M += f * s.M
+			}
+		}
+	}
+	return out
+}
+
+// zeroFrame copies the layout of a result with every value zeroed.
+func zeroFrame(res FrameResult) FrameResult {
+	out := FrameResult{
+		Displacements: make([]Displacement, len(res.Displacements)),
+		Reactions:     make([]NodeReaction, len(res.Reactions)),
+		Members:       make([]MemberResult, len(res.Members)),
+	}
+	for i, d := range res.Displacements {
+		out.Displacements[i] = Displacement{Node: d.Node}
+	}
+	for i, r := range res.Reactions {
+		out.Reactions[i] = NodeReaction{Node: r.Node}
+	}
+	for i, m := range res.Members {
+		out.Members[i] = m
+		out.Members[i].Stations = make([]Actions, len(m.Stations))
+		for j, s := range m.Stations {
+			out.Members[i].Stations[j] = Actions{X: s.X}
+		}
+	}
+	return out
+}
+
+// MemberDesign is the worst strength check of a frame member over the
+// combinations.
+type MemberDesign struct {
+	Member      Member
+	Combination string
+	N           float64 // Design axial force, compression positive (kN)
+	M           float64 // Design moment magnitude (kNm)
+	V           float64 // Design shear magnitude (kN)
+	Combined    calc.CombinedResult
+	ShearRatio  float64
+	Ratio       float64 // Governing ratio, combined actions or shear
+	Description string  // What the governing ratio checks
+}
+
+// Passes reports whether the member is within capacity.
+func (d MemberDesign) Passes() bool {
+	return d.Ratio <= 1
+}
+
+// DesignMembers checks every member under each strength combination result
+// to AS 4100 Section 8 and Cl. 5.11, taking the largest compression (or
+// least tension), moment and shear along the member together. βm is taken
+// as -1.
+func DesignMembers(combined []FrameResult) ([]MemberDesign, error) {
+	if len(combined) == 0 {
+		return nil, nil
+	}
+	designs := make([]MemberDesign, len(combined[0].Members))
+	for i, m := range combined[0].Members {
+		designs[i] = MemberDesign{Member: m.Member, Ratio: -1}
+	}
+	for _, res := range combined {
+		for i, mr := range res.Members {
+			member := mr.Member
+			n := math.Inf(-1)
+			mMax, vMax := 0.0, 0.0
+			for _, s := range mr.Stations {
+				n = math.Max(n, -s.N)
+				mMax = math.Max(mMax, math.Abs(s.M))
+				vMax = math.Max(vMax, math.Abs(s.V))
+			}
+			a := calc.CombinedActions{
+				N:      n,
+				Lex:    orLength(member.Lex, mr.Length),
+				Ley:    orLength(member.Ley, mr.Length),
+				Le:     orLength(member.Le, mr.Length),
+				AlphaM: member.AlphaM,
+				BetaMx: -1,
+				BetaMy: -1,
+			}
+			if member.MinorAxis {
+				a.My = mMax
+			} else {
+				a.Mx = mMax
+			}
+			cr, err := calc.CheckCombined(member.Property, a)
+			if err != nil {
+				return nil, fmt.Errorf("member '%s': %w", member.ID, err)
+			}
+			sc := calc.Section(member.Property)
+			shear := 0.0
+			if sc.PhiVv > 0 && !member.MinorAxis {
+				shear = vMax / sc.PhiVv
+			}
+			ratio, description := cr.Governing.Ratio, cr.Governing.Description
+			if shear > ratio {
+				ratio, description = shear, "shear, Cl. 5.11"
+			}
+			if ratio > designs[i].Ratio {
+				designs[i] = MemberDesign{
+					Member:      member,
+					Combination: res.LoadCase,
+					N:           n,
+					M:           mMax,
+					V:           vMax,
+					Combined:    cr,
+					ShearRatio:  shear,
+					Ratio:       ratio,
+					Description: description,
+				}
+			}
+		}
+	}
+	return designs, nil
+}
+
+func orLength(value, length float64) float64 {
+	if value > 0 {
+		return value
+	}
+	return length
+}
+
+// AnalyseBeamCombinations analyses a beam whose loads carry load case names
+// under each combination. Shear, moment and reactions are enveloped over the
+// strength combinations and deflection over the serviceability
+// combinations. Imposed loads are patterned span by span.
+func AnalyseBeamCombinations(b Beam, combos []loads.Combination) (*BeamResult, error) {
+	var strength, service *BeamResult
+	for _, c := range combos {
+		factored := b
+		factored.Loads = make([]BeamLoad, len(b.Loads))
+		for i, ld := range b.Loads {
+			ld.Value *= c.Factor(ld.LoadCase)
+			kind, _ := loads.KindOf(ld.LoadCase)
+			ld.Pattern = kind == loads.Imposed
+			factored.Loads[i] = ld
+		}
+		res, err := AnalyseBeam(factored)
+		if err != nil {
+			return nil, err
+		}
+		if c.State == loads.Strength {
+			strength = mergeBeam(strength, res)
+		} else {
+			service = mergeBeam(service, res)
+		}
+	}
+	if strength == nil {
+		return nil, fmt.Errorf("no strength combinations to analyse")
+	}
+	strength.Beam = b
+	for i := range strength.Stations {
+		if service == nil {
+			strength.Stations[i].Deflection = Range{}
+			continue
+		}
+		strength.Stations[i].Deflection = service.Stations[i].Deflection
+	}
+	return strength, nil
+}
+
+// mergeBeam widens the envelope in a with the results in b. Both must come
+// from the same beam and load positions.
+func mergeBeam(a, b *BeamResult) *BeamResult {
+	if a == nil {
+		return b
+	}
+	for i, s := range b.Stations {
+		o := &a.Stations[i]
+		o.Shear = o.Shear.merge(s.Shear)
+		o.Moment = o.Moment.merge(s.Moment)
+		o.Deflection = o.Deflection.merge(s.Deflection)
+	}
+	for i, r := range b.Reactions {
+		o := &a.Reactions[i]
+		o.Force = o.Force.merge(r.Force)
+		o.Moment = o.Moment.merge(r.Moment)
+	}
+	return a
+}
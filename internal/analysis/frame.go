@@ -0,0 +1,598 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"steel_tables/internal/calc"
+	"steel_tables/internal/models"
+)
+
+// Restraint marks the restrained degrees of freedom at a node.
+type Restraint struct {
+	X, Y, R bool
+}
+
+// ParseRestraint converts a support name to a Restraint. A roller resists
+// vertical load; roller-x resists horizontal load.
+func ParseRestraint(value string) (Restraint, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "free", "none":
+		return Restraint{}, nil
+	case "pin", "pinned":
+		return Restraint{X: true, Y: true}, nil
+	case "fixed", "fix":
+		return Restraint{X: true, Y: true, R: true}, nil
+	case "roller", "roller-y":
+		return Restraint{Y: true}, nil
+	case "roller-x":
+		return Restraint{X: true}, nil
+	}
+	return Restraint{}, fmt.Errorf("invalid support '%s' (want pin, fixed, roller, roller-x or free)", value)
+}
+
+// Any reports whether any degree of freedom is restrained.
+func (r Restraint) Any() bool {
+	return r.X || r.Y || r.R
+}
+
+// Node is a frame joint. Coordinates are in m with y upward.
+type Node struct {
+	ID        string
+	X, Y      float64
+	Restraint Restraint
+}
+
+// Member is a prismatic frame member between two nodes.
+type Member struct {
+	ID           string
+	Start, End   string
+	Section      string  // Catalog designation, for reporting
+	A            float64 // Gross area (mm²)
+	I            float64 // Second moment of area about the bending axis (10⁶mm⁴)
+	ReleaseStart bool    // Moment release (pin) at the start
+	ReleaseEnd   bool    // Moment release (pin) at the end
+
+	// Design data for member checks; zero lengths default to the member
+	// length.
+	Property  models.SteelProperty
+	MinorAxis bool    // Bending about the section's y axis
+	Lex, Ley  float64 // Compression effective lengths (m)
+	Le        float64 // Lateral-torsional buckling effective length (m)
+	AlphaM    float64 // Moment modification factor αm
+}
+
+// NodalLoad is a force in kN and moment in kNm applied at a node, in global
+// axes with y upward and moments anticlockwise.
+type NodalLoad struct {
+	Node     string
+	FX, FY   float64
+	M        float64
+	LoadCase string
+}
+
+// MemberLoad is a point load (kN) or a UDL along the full member length
+// (kN/m of member length). X and Y are in global axes, or along and
+// perpendicular to the member when Local is set.
+type MemberLoad struct {
+	Member   string
+	Kind     LoadKind
+	X, Y     float64
+	At       float64 // Point load distance from the start node (m)
+	Local    bool
+	LoadCase string
+}
+
+// Frame is a plane frame model.
+type Frame struct {
+	Nodes       []Node
+	Members     []Member
+	NodalLoads  []NodalLoad
+	MemberLoads []MemberLoad
+}
+
+// Validate checks that the frame refers to known nodes and members.
+func (f Frame) Validate() error {
+	if len(f.Members) == 0 {
+		return fmt.Errorf("frame has no members")
+	}
+	nodes := map[string]Node{}
+	for _, n := range f.Nodes {
+		if _, ok := nodes[n.ID]; ok {
+			return fmt.Errorf("duplicate node '%s'", n.ID)
+		}
+		nodes[n.ID] = n
+	}
+	members := map[string]Member{}
+	for _, m := range f.Members {
+		if _, ok := members[m.ID]; ok {
+			return fmt.Errorf("duplicate member '%s'", m.ID)
+		}
+		a, ok1 := nodes[m.Start]
+		b, ok2 := nodes[m.End]
+		if !ok1 || !ok2 {
+			return fmt.Errorf("member '%s' refers to an unknown node", m.ID)
+		}
+		if a.X == b.X && a.Y == b.Y {
+			return fmt.Errorf("member '%s' has zero length", m.ID)
+		}
+		if m.A <= 0 || m.I <= 0 {
+			return fmt.Errorf("member '%s' needs a positive area and second moment of area", m.ID)
+		}
+		members[m.ID] = m
+	}
+	for _, l := range f.NodalLoads {
+		if _, ok := nodes[l.Node]; !ok {
+			return fmt.Errorf("load on unknown node '%s'", l.Node)
+		}
+	}
+	for _, l := range f.MemberLoads {
+		m, ok := members[l.Member]
+		if !ok {
+			return fmt.Errorf("load on unknown member '%s'", l.Member)
+		}
+		length := memberLength(nodes[m.Start], nodes[m.End])
+		if l.Kind == PointLoad && (l.At < 0 || l.At > length+1e-9) {
+			return fmt.Errorf("point load at %.2f m is outside member '%s' (%.2f m)", l.At, m.ID, length)
+		}
+	}
+	return nil
+}
+
+// LoadCases returns the names of the load cases used by the frame's loads,
+// in order of first use.
+func (f Frame) LoadCases() []string {
+	var cases []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			cases = append(cases, name)
+		}
+	}
+	for _, l := range f.NodalLoads {
+		add(l.LoadCase)
+	}
+	for _, l := range f.MemberLoads {
+		add(l.LoadCase)
+	}
+	return cases
+}
+
+func memberLength(a, b Node) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
+
+// Displacement is the movement of a node: mm and radians, anticlockwise.
+type Displacement struct {
+	Node     string
+	DX, DY   float64
+	Rotation float64
+}
+
+// NodeReaction is the support reaction at a node in kN and kNm.
+type NodeReaction struct {
+	Node   string
+	FX, FY float64
+	M      float64
+}
+
+// Actions are the internal actions at a point along a member: axial force
+// N (kN, tension positive), shear V (kN) and moment M (kNm, positive when
+// the member's local +y face is in compression).
+type Actions struct {
+	X       float64 // Distance from the start node (m)
+	N, V, M float64
+}
+
+// MemberResult holds the internal actions along a member.
+type MemberResult struct {
+	Member   Member
+	Length   float64 // m
+	Angle    float64 // Degrees from the global x axis
+	Stations []Actions
+}
+
+// Start returns the actions at the start node.
+func (m MemberResult) Start() Actions {
+	return m.Stations[0]
+}
+
+// End returns the actions at the end node.
+func (m MemberResult) End() Actions {
+	return m.Stations[len(m.Stations)-1]
+}
+
+// Max returns the largest magnitudes of N, V and M along the member.
+func (m MemberResult) Max() Actions {
+	var a Actions
+	for _, s := range m.Stations {
+		if math.Abs(s.N) > math.Abs(a.N) {
+			a.N = s.N
+		}
+		if math.Abs(s.V) > math.Abs(a.V) {
+			a.V = s.V
+		}
+		if math.Abs(s.M) > math.Abs(a.M) {
+			a.M = s.M
+		}
+	}
+	return a
+}
+
+// FrameResult holds the solution of a frame for one load case.
+type FrameResult struct {
+	LoadCase      string
+	Displacements []Displacement
+	Reactions     []NodeReaction
+	Members       []MemberResult
+}
+
+// memberStations is the number of segments each member's actions are
+// reported at, in addition to point load positions in any load case.
+const memberStations = 20
+
+// element holds a member's geometry and condensed local stiffness.
+type element struct {
+	member Member
+	start  int // node indices
+	end    int
+	length float64 // mm
+	c, s   float64 // direction cosines
+	k      [][]float64
+}
+
+// localLoad is a member load resolved into the member's axes, in N and N/mm.
+type localLoad struct {
+	kind        LoadKind
+	axial, perp float64
+	at          float64 // mm
+}
+
+// AnalyseFrame solves the frame for every load case and returns one result
+// per case in the order of Frame.LoadCases.
+func AnalyseFrame(f Frame) ([]FrameResult, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	cases := f.LoadCases()
+	if len(cases) == 0 {
+		cases = []string{""}
+	}
+	caseIndex := map[string]int{}
+	for i, c := range cases {
+		caseIndex[c] = i
+	}
+	nodeIndex := map[string]int{}
+	for i, n := range f.Nodes {
+		nodeIndex[n.ID] = i
+	}
+
+	elements := make([]element, len(f.Members))
+	for i, m := range f.Members {
+		a, b := f.Nodes[nodeIndex[m.Start]], f.Nodes[nodeIndex[m.End]]
+		l := memberLength(a, b) * 1000
+		e := element{
+			member: m,
+			start:  nodeIndex[m.Start],
+			end:    nodeIndex[m.End],
+			length: l,
+			c:      (b.X - a.X) * 1000 / l,
+			s:      (b.Y - a.Y) * 1000 / l,
+		}
+		e.k = frameStiffness(calc.E*m.A, calc.E*m.I*1e6, l)
+		condense(e.k, nil, e.releases())
+		elements[i] = e
+	}
+
+	// Member loads in local axes, per element and case.
+	loads := make([][][]localLoad, len(elements))
+	memberIndex := map[string]int{}
+	for i, m := range f.Members {
+		memberIndex[m.ID] = i
+		loads[i] = make([][]localLoad, len(cases))
+	}
+	for _, l := range f.MemberLoads {
+		i := memberIndex[l.Member]
+		e := elements[i]
+		x, y := l.X, l.Y
+		if !l.Local {
+			x, y = l.X*e.c+l.Y*e.s, -l.X*e.s+l.Y*e.c
+		}
+		scale := 1.0 // kN/m is N/mm
+		if l.Kind == PointLoad {
+			scale = 1000
+		}
+		c := caseIndex[l.LoadCase]
+		loads[i][c] = append(loads[i][c], localLoad{l.Kind, x * scale, y * scale, l.At * 1000})
+	}
+
+	sys := newSystem(3*len(f.Nodes), len(cases), 0)
+	for i, e := range elements {
+		dofs := e.dofs()
+		sys.add(e.globalStiffness(), dofs)
+		for c := range cases {
+			fixed := fixedEndForces(e, loads[i][c])
+			global := e.toGlobal(fixed)
+			for j, d := range dofs {
+				sys.loads[c][d] -= global[j]
+			}
+		}
+	}
+	for _, l := range f.NodalLoads {
+		n, c := nodeIndex[l.Node], caseIndex[l.LoadCase]
+		sys.loads[c][3*n] += l.FX * 1000
+		sys.loads[c][3*n+1] += l.FY * 1000
+		sys.loads[c][3*n+2] += l.M * 1e6
+	}
+
+	// A node where every member is released has no rotational stiffness;
+	// restrain its rotation so the system stays solvable.
+	scale := 0.0
+	for i := range sys.k {
+		scale = math.Max(scale, math.Abs(sys.k[i][i]))
+	}
+	pinnedJoint := make([]bool, len(f.Nodes))
+	for i, n := range f.Nodes {
+		sys.restrained[3*i] = n.Restraint.X
+		sys.restrained[3*i+1] = n.Restraint.Y
+		sys.restrained[3*i+2] = n.Restraint.R
+		if !n.Restraint.R && math.Abs(sys.k[3*i+2][3*i+2]) <= scale*1e-12 {
+			sys.restrained[3*i+2] = true
+			pinnedJoint[i] = true
+		}
+	}
+
+	d, err := sys.solve()
+	if err != nil {
+		return nil, err
+	}
+
+	// Every case reports actions at the same stations so that results can
+	// be combined.
+	stations := make([][]float64, len(elements))
+	for i, e := range elements {
+		for k := 0; k <= memberStations; k++ {
+			stations[i] = append(stations[i], e.length*float64(k)/memberStations)
+		}
+		for c := range cases {
+			for _, ld := range loads[i][c] {
+				if ld.kind == PointLoad {
+					stations[i] = append(stations[i], ld.at, ld.at)
+				}
+			}
+		}
+		sort.Float64s(stations[i])
+	}
+
+	results := make([]FrameResult, len(cases))
+	for c, name := range cases {
+		res := FrameResult{LoadCase: name}
+		for i, n := range f.Nodes {
+			res.Displacements = append(res.Displacements, Displacement{
+				Node:     n.ID,
+				DX:       d[c][3*i],
+				DY:       d[c][3*i+1],
+				Rotation: d[c][3*i+2],
+			})
+			if !n.Restraint.Any() {
+				continue
+			}
+			r := NodeReaction{Node: n.ID}
+			if n.Restraint.X {
+				r.FX = sys.reaction(3*i, c, d[c]) / 1000
+			}
+			if n.Restraint.Y {
+				r.FY = sys.reaction(3*i+1, c, d[c]) / 1000
+			}
+			if n.Restraint.R && !pinnedJoint[i] {
+				r.M = sys.reaction(3*i+2, c, d[c]) / 1e6
+			}
+			res.Reactions = append(res.Reactions, r)
+		}
+		for i, e := range elements {
+			local := e.toLocal(d[c], e.dofs())
+			ends := fixedEndForces(e, loads[i][c])
+			for r := range ends {
+				for j := range local {
+					ends[r] += e.k[r][j] * local[j]
+				}
+			}
+			res.Members = append(res.Members, e.actions(ends, loads[i][c], stations[i]))
+		}
+		results[c] = res
+	}
+	// Rotations at released joints are not defined by the solution.
+	for c := range results {
+		for i := range results[c].Displacements {
+			if pinnedJoint[i] {
+				results[c].Displacements[i].Rotation = 0
+			}
+		}
+	}
+	return results, nil
+}
+
+// releases returns the local dofs released by member end pins.
+func (e element) releases() []int {
+	var r []int
+	if e.member.ReleaseStart {
+		r = append(r, 2)
+	}
+	if e.member.ReleaseEnd {
+		r = append(r, 5)
+	}
+	return r
+}
+
+func (e element) dofs() []int {
+	return []int{3 * e.start, 3*e.start + 1, 3*e.start + 2, 3 * e.end, 3*e.end + 1, 3*e.end + 2}
+}
+
+// toGlobal rotates a local end force vector into global axes.
+func (e element) toGlobal(v []float64) []float64 {
+	return []float64{
+		e.c*v[0] - e.s*v[1], e.s*v[0] + e.c*v[1], v[2],
+		e.c*v[3] - e.s*v[4], e.s*v[3] + e.c*v[4], v[5],
+	}
+}
+
+// toLocal picks the element's global displacements and rotates them into
+// local axes.
+func (e element) toLocal(d []float64, dofs []int) []float64 {
+	g := make([]float64, 6)
+	for i, dof := range dofs {
+		g[i] = d[dof]
+	}
+	return []float64{
+		e.c*g[0] + e.s*g[1], -e.s*g[0] + e.c*g[1], g[2],
+		e.c*g[3] + e.s*g[4], -e.s*g[3] + e.c*g[4], g[5],
+	}
+}
+
+// globalStiffness returns Tᵀ k T.
+func (e element) globalStiffness() [][]float64 {
+	t := [][]float64{
+		{e.c, e.s, 0, 0, 0, 0},
+		{-e.s, e.c, 0, 0, 0, 0},
+		{0, 0, 1, 0, 0, 0},
+		{0, 0, 0, e.c, e.s, 0},
+		{0, 0, 0, -e.s, e.c, 0},
+		{0, 0, 0, 0, 0, 1},
+	}
+	kg := make([][]float64, 6)
+	for i := range kg {
+		kg[i] = make([]float64, 6)
+		for j := range kg[i] {
+			for a := 0; a < 6; a++ {
+				if t[a][i] == 0 {
+					continue
+				}
+				for b := 0; b < 6; b++ {
+					kg[i][j] += t[a][i] * e.k[a][b] * t[b][j]
+				}
+			}
+		}
+	}
+	return kg
+}
+
+// frameStiffness returns the local stiffness matrix of a plane frame element
+// for the dofs (u1, v1, θ1, u2, v2, θ2).
+func frameStiffness(ea, ei, l float64) [][]float64 {
+	a := ea / l
+	b := 12 * ei / (l * l * l)
+	c := 6 * ei / (l * l)
+	d := 4 * ei / l
+	e := 2 * ei / l
+	return [][]float64{
+		{a, 0, 0, -a, 0, 0},
+		{0, b, c, 0, -b, c},
+		{0, c, d, 0, -c, e},
+		{-a, 0, 0, a, 0, 0},
+		{0, -b, -c, 0, b, -c},
+		{0, c, e, 0, -c, d},
+	}
+}
+
+// condense removes the released dofs from k, and from the fixed end forces
+// f if given, by static condensation.
+func condense(k [][]float64, f []float64, released []int) {
+	for _, r := range released {
+		krr := k[r][r]
+		if krr == 0 {
+			continue
+		}
+		for i := range k {
+			if i == r {
+				continue
+			}
+			kir := k[i][r]
+			if f != nil {
+				f[i] -= kir * f[r] / krr
+			}
+			for j := range k {
+				if j != r {
+					k[i][j] -= kir * k[r][j] / krr
+				}
+			}
+		}
+		for i := range k {
+			k[i][r], k[r][i] = 0, 0
+		}
+		if f != nil {
+			f[r] = 0
+		}
+	}
+}
+
+// fixedEndForces returns the end forces, in local axes, that a member with
+// both ends fixed exerts on its nodes' restraints under the loads, condensed
+// for any releases.
+func fixedEndForces(e element, loads []localLoad) []float64 {
+	f := make([]float64, 6)
+	l := e.length
+	for _, ld := range loads {
+		switch ld.kind {
+		case DistributedLoad:
+			f[0] -= ld.axial * l / 2
+			f[1] -= ld.perp * l / 2
+			f[2] -= ld.perp * l * l / 12
+			f[3] -= ld.axial * l / 2
+			f[4] -= ld.perp * l / 2
+			f[5] += ld.perp * l * l / 12
+		case PointLoad:
+			a, b := ld.at, l-ld.at
+			f[0] -= ld.axial * b / l
+			f[1] -= ld.perp * b * b * (3*a + b) / (l * l * l)
+			f[2] -= ld.perp * a * b * b / (l * l)
+			f[3] -= ld.axial * a / l
+			f[4] -= ld.perp * a * a * (a + 3*b) / (l * l * l)
+			f[5] += ld.perp * a * a * b / (l * l)
+		}
+	}
+	if r := e.releases(); len(r) > 0 {
+		k := frameStiffness(calc.E*e.member.A, calc.E*e.member.I*1e6, l)
+		condense(k, f, r)
+	}
+	return f
+}
+
+// actions returns the internal actions at positions (mm) along the member
+// from its local end forces f, the forces the nodes exert on the member.
+func (e element) actions(f []float64, loads []localLoad, positions []float64) MemberResult {
+	l := e.length
+	res := MemberResult{
+		Member: e.member,
+		Length: l / 1000,
+		Angle:  math.Atan2(e.s, e.c) * 180 / math.Pi,
+	}
+	// At a point load the first station is just before it and the second
+	// just after.
+	var last float64 = -1
+	for _, x := range positions {
+		after := x == last
+		last = x
+		n := -f[0]
+		v := f[1]
+		m := -f[2] + f[1]*x
+		for _, ld := range loads {
+			switch ld.kind {
+			case DistributedLoad:
+				n -= ld.axial * x
+				v += ld.perp * x
+				m += ld.perp * x * x / 2
+			case PointLoad:
+				if ld.at < x || (ld.at == x && after) || (x == l && ld.at == l) {
+					n -= ld.axial
+					v += ld.perp
+					m += ld.perp * (x - ld.at)
+				}
+			}
+		}
+		res.Stations = append(res.Stations, Actions{X: x / 1000, N: n / 1000, V: v / 1000, M: m / 1e6})
+	}
+	return res
+}
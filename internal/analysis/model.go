@@ -0,0 +1,120 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"steel_tables/internal/compound"
+)
+
+// frameFile is the JSON layout of a frame model file.
+type frameFile struct {
+	Nodes []struct {
+		ID      string  `json:"id"`
+		X       float64 `json:"x"`
+		Y       float64 `json:"y"`
+		Support string  `json:"support"`
+	} `json:"nodes"`
+	Members []struct {
+		ID      string  `json:"id"`
+		Start   string  `json:"start"`
+		End     string  `json:"end"`
+		Section string  `json:"section"`
+		Grade   int     `json:"grade"`
+		Axis    string  `json:"axis"`
+		Release string  `json:"release"`
+		Lex     float64 `json:"lex"`
+		Ley     float64 `json:"ley"`
+		Le      float64 `json:"le"`
+		AlphaM  float64 `json:"am"`
+	} `json:"members"`
+	Loads []struct {
+		Case   string  `json:"case"`
+		Node   string  `json:"node"`
+		FX     float64 `json:"fx"`
+		FY     float64 `json:"fy"`
+		M      float64 `json:"m"`
+		Member string  `json:"member"`
+		Type   string  `json:"type"`
+		X      float64 `json:"x"`
+		Y      float64 `json:"y"`
+		At     float64 `json:"at"`
+		Local  bool    `json:"local"`
+	} `json:"loads"`
+}
+
+// LoadFrame reads a frame model from a JSON file, looking up each member's
+// section in the catalog for its area and second moment of area.
+func LoadFrame(path string) (Frame, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return Frame{}, err
+	}
+	defer r.Close()
+	var file frameFile
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return Frame{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var f Frame
+	for _, n := range file.Nodes {
+		r, err := ParseRestraint(n.Support)
+		if err != nil {
+			return Frame{}, fmt.Errorf("node '%s': %w", n.ID, err)
+		}
+		f.Nodes = append(f.Nodes, Node{ID: n.ID, X: n.X, Y: n.Y, Restraint: r})
+	}
+	for _, m := range file.Members {
+		match, err := compound.FindOne(m.Section, m.Grade)
+		if err != nil {
+			return Frame{}, fmt.Errorf("member '%s': %w", m.ID, err)
+		}
+		p := match.Property
+		member := Member{ID: m.ID, Start: m.Start, End: m.End, Section: p.Section, A: p.Ag, I: p.Ix,
+			Property: p, Lex: m.Lex, Ley: m.Ley, Le: m.Le, AlphaM: m.AlphaM}
+		switch strings.ToLower(m.Axis) {
+		case "", "x":
+		case "y":
+			member.I = p.Iy
+			member.MinorAxis = true
+		default:
+			return Frame{}, fmt.Errorf("member '%s': invalid axis '%s' (want x or y)", m.ID, m.Axis)
+		}
+		switch strings.ToLower(m.Release) {
+		case "", "none":
+		case "start":
+			member.ReleaseStart = true
+		case "end":
+			member.ReleaseEnd = true
+		case "both":
+			member.ReleaseStart, member.ReleaseEnd = true, true
+		default:
+			return Frame{}, fmt.Errorf("member '%s': invalid release '%s' (want start, end or both)", m.ID, m.Release)
+		}
+		f.Members = append(f.Members, member)
+	}
+	for i, l := range file.Loads {
+		switch {
+		case l.Node != "" && l.Member == "":
+			f.NodalLoads = append(f.NodalLoads, NodalLoad{Node: l.Node, FX: l.FX, FY: l.FY, M: l.M, LoadCase: l.Case})
+		case l.Member != "" && l.Node == "":
+			ml := MemberLoad{Member: l.Member, X: l.X, Y: l.Y, At: l.At, Local: l.Local, LoadCase: l.Case}
+			switch strings.ToLower(l.Type) {
+			case "udl":
+				ml.Kind = DistributedLoad
+			case "point":
+				ml.Kind = PointLoad
+			default:
+				return Frame{}, fmt.Errorf("load %d: invalid type '%s' (want udl or point)", i+1, l.Type)
+			}
+			f.MemberLoads = append(f.MemberLoads, ml)
+		default:
+			return Frame{}, fmt.Errorf("load %d: give either a node or a member", i+1)
+		}
+	}
+	return f, f.Validate()
+}
@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+)
+
+// system is a symmetric stiffness matrix with its load vectors, solved for
+// displacements with some degrees of freedom restrained to zero.
+type system struct {
+	k          [][]float64
+	loads      [][]float64 // one load vector per load case
+	restrained []bool
+	band       int // half bandwidth; 0 for a full matrix
+}
+
+func newSystem(dofs, cases, band int) *system {
+	s := &system{
+		k:          make([][]float64, dofs),
+		loads:      make([][]float64, cases),
+		restrained: make([]bool, dofs),
+		band:       band,
+	}
+	for i := range s.k {
+		s.k[i] = make([]float64, dofs)
+	}
+	for i := range s.loads {
+		s.loads[i] = make([]float64, dofs)
+	}
+	return s
+}
+
+// add assembles an element matrix into the global matrix at the given dofs.
+func (s *system) add(ke [][]float64, dofs []int) {
+	for i, gi := range dofs {
+		for j, gj := range dofs {
+			s.k[gi][gj] += ke[i][j]
+		}
+	}
+}
+
+// solve returns the displacements for every load case. The global matrix is
+// left untouched so reactions can be recovered from it afterwards.
+func (s *system) solve() ([][]float64, error) {
+	n := len(s.k)
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = append([]float64(nil), s.k[i]...)
+	}
+	b := make([][]float64, len(s.loads))
+	for c := range b {
+		b[c] = append([]float64(nil), s.loads[c]...)
+	}
+
+	scale := 0.0
+	for i := 0; i < n; i++ {
+		scale = math.Max(scale, math.Abs(a[i][i]))
+	}
+	for i := 0; i < n; i++ {
+		if !s.restrained[i] {
+			continue
+		}
+		for j := 0; j < n; j++ {
+			a[i][j], a[j][i] = 0, 0
+		}
+		a[i][i] = scale
+		for c := range b {
+			b[c][i] = 0
+		}
+	}
+
+	// Gaussian elimination without pivoting; the matrix is symmetric and
+	// positive definite unless the structure is a mechanism.
+	for p := 0; p < n; p++ {
+		if math.Abs(a[p][p]) <= scale*1e-12 {
+			return nil, fmt.Errorf("structure is unstable: add supports or restraints")
+		}
+		last := n - 1
+		if s.band > 0 {
+			last = min(n-1, p+s.band)
+		}
+		for i := p + 1; i <= last; i++ {
+			f := a[i][p] / a[p][p]
+			if f == 0 {
+				continue
+			}
+			for j := p; j <= last; j++ {
+				a[i][j] -= f * a[p][j]
+			}
+			for c := range b {
+				b[c][i] -= f * b[c][p]
+			}
+		}
+	}
+	for c := range b {
+		x := b[c]
+		for i := n - 1; i >= 0; i-- {
+			last := n - 1
+			if s.band > 0 {
+				last = min(n-1, i+s.band)
+			}
+			sum := x[i]
+			for j := i + 1; j <= last; j++ {
+				sum -= a[i][j] * x[j]
+			}
+			x[i] = sum / a[i][i]
+		}
+	}
+	return b, nil
+}
+
+// reaction returns the force at dof i for the displacements d of case c.
+func (s *system) reaction(i, c int, d []float64) float64 {
+	r := -s.loads[c][i]
+	for j, kij := range s.k[i] {
+		r += kij * d[j]
+	}
+	return r
+}
@@ -0,0 +1,295 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"steel_tables/internal/calc"
+	"steel_tables/internal/compound"
+	"steel_tables/internal/models"
+)
+
+// TorsionEnd is the torsional restraint at one end of a member.
+type TorsionEnd int
+
+const (
+	TorsionPinned TorsionEnd = iota // Twist prevented, warping free
+	TorsionFixed                    // Twist and warping prevented
+	TorsionFree                     // Unrestrained
+)
+
+// ParseTorsionEnd converts an end restraint name to a TorsionEnd.
+func ParseTorsionEnd(value string) (TorsionEnd, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "pin", "pinned":
+		return TorsionPinned, nil
+	case "fixed", "fix":
+		return TorsionFixed, nil
+	case "free", "none":
+		return TorsionFree, nil
+	}
+	return TorsionPinned, fmt.Errorf("invalid torsion end '%s' (want pinned, fixed or free)", value)
+}
+
+func (e TorsionEnd) String() string {
+	switch e {
+	case TorsionFixed:
+		return "fixed"
+	case TorsionFree:
+		return "free"
+	default:
+		return "pinned"
+	}
+}
+
+// PointTorque is a concentrated torque T (kNm) at At m from the left end.
+type PointTorque struct {
+	T  float64
+	At float64
+}
+
+// TorsionMember is a single member under torque. Pinned ends prevent twist
+// but leave the flanges free to warp; fixed ends also prevent warping.
+type TorsionMember struct {
+	Length      float64       // m
+	Ends        [2]TorsionEnd // Left and right
+	J           float64       // Torsion constant (10³mm⁴)
+	Iw          float64       // Warping constant (10⁹mm⁶)
+	Torques     []PointTorque
+	Distributed float64 // Uniform torque over the whole length (kNm/m)
+}
+
+// Validate checks the member and its loads.
+func (m TorsionMember) Validate() error {
+	if m.Length <= 0 {
+		return fmt.Errorf("member length must be positive")
+	}
+	if m.J <= 0 || m.Iw <= 0 {
+		return fmt.Errorf("torsion analysis needs J and Iw")
+	}
+	if m.Ends[0] == TorsionFree && m.Ends[1] == TorsionFree {
+		return fmt.Errorf("at least one end must be restrained against twist")
+	}
+	for _, t := range m.Torques {
+		if t.At < 0 || t.At > m.Length+1e-9 {
+			return fmt.Errorf("torque at %.2f m is outside the %.2f m member", t.At, m.Length)
+		}
+	}
+	return nil
+}
+
+// TorsionStation holds the torsion results at a point along the member. The
+// total torque T is the sum of the applied torques to the right; it is shared
+// between St Venant torsion GJ dφ/dz and warping torsion -EIw d³φ/dz³. The
+// bimoment is -EIw d²φ/dz².
+type TorsionStation struct {
+	X        float64 // Distance from the left end (m)
+	Twist    float64 // φ (rad)
+	Rate     float64 // Rate of twist dφ/dz (rad/m)
+	Total    float64 // kNm
+	StVenant float64 // kNm
+	Warping  float64 // kNm
+	Bimoment float64 // kNm²
+}
+
+// TorsionResult holds the analysis of a member under torque. Stations come
+// in pairs at each node, either side of it, so torque steps at concentrated
+// torques are kept.
+type TorsionResult struct {
+	Member   TorsionMember
+	A        float64 // Torsion parameter a = √(EIw/GJ) (m)
+	Stations []TorsionStation
+}
+
+// torsionDivisions is the number of elements the member is split into
+// before adding nodes at concentrated torques.
+const torsionDivisions = 200
+
+// torsionStiffness returns the 4x4 stiffness matrix of a thin-walled element
+// for the dofs (φ1, φ1', φ2, φ2'): the warping stiffness has the form of a
+// beam's flexural stiffness and the St Venant stiffness that of a tie's
+// geometric stiffness.
+func torsionStiffness(eiw, gj, l float64) [][]float64 {
+	k := beamStiffness(eiw, l)
+	c := gj / (30 * l)
+	g := [][]float64{
+		{36, 3 * l, -36, 3 * l},
+		{3 * l, 4 * l * l, -3 * l, -l * l},
+		{-36, -3 * l, 36, -3 * l},
+		{3 * l, -l * l, -3 * l, 4 * l * l},
+	}
+	for i := range k {
+		for j := range k[i] {
+			k[i][j] += c * g[i][j]
+		}
+	}
+	return k
+}
+
+// AnalyseTorsion solves a member for its twist and the distribution of
+// torque between St Venant and warping torsion.
+func AnalyseTorsion(m TorsionMember) (*TorsionResult, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	length := m.Length * 1000
+	points := []float64{}
+	for k := 0; k <= torsionDivisions; k++ {
+		points = append(points, length*float64(k)/torsionDivisions)
+	}
+	for _, t := range m.Torques {
+		points = append(points, t.At*1000)
+	}
+	sort.Float64s(points)
+	var x []float64
+	for _, p := range points {
+		if len(x) == 0 || p-x[len(x)-1] > 1e-6 {
+			x = append(x, p)
+		}
+	}
+
+	eiw := calc.E * m.Iw * 1e9
+	gj := calc.G * m.J * 1e3
+	nodes := len(x)
+	sys := newSystem(2*nodes, 1, 3)
+	for e := 0; e < nodes-1; e++ {
+		sys.add(torsionStiffness(eiw, gj, x[e+1]-x[e]), []int{2 * e, 2*e + 1, 2*e + 2, 2*e + 3})
+	}
+	for _, t := range m.Torques {
+		sys.loads[0][2*nearestNode(x, t.At*1000)] += t.T * 1e6
+	}
+	w := m.Distributed * 1e3 // Nmm/mm
+	fixed := func(l float64) []float64 {
+		return []float64{w * l / 2, w * l * l / 12, w * l / 2, -w * l * l / 12}
+	}
+	for e := 0; e < nodes-1 && w != 0; e++ {
+		for i, f := range fixed(x[e+1] - x[e]) {
+			sys.loads[0][2*e+i] += f
+		}
+	}
+	for i, end := range m.Ends {
+		n := i * (nodes - 1)
+		switch end {
+		case TorsionPinned:
+			sys.restrained[2*n] = true
+		case TorsionFixed:
+			sys.restrained[2*n] = true
+			sys.restrained[2*n+1] = true
+		}
+	}
+
+	d, err := sys.solve()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TorsionResult{Member: m, A: math.Sqrt(eiw/gj) / 1000}
+	station := func(n int, total, bimoment float64) TorsionStation {
+		rate := d[0][2*n+1]
+		sv := gj * rate
+		return TorsionStation{
+			X:        x[n] / 1000,
+			Twist:    d[0][2*n],
+			Rate:     rate * 1000,
+			Total:    total * 1e-6,
+			StVenant: sv * 1e-6,
+			Warping:  (total - sv) * 1e-6,
+			Bimoment: bimoment * 1e-9,
+		}
+	}
+	for e := 0; e < nodes-1; e++ {
+		l := x[e+1] - x[e]
+		ke := torsionStiffness(eiw, gj, l)
+		de := d[0][2*e : 2*e+4]
+		fe := fixed(l)
+		f := make([]float64, 4)
+		for i := range f {
+			for j := range de {
+				f[i] += ke[i][j] * de[j]
+			}
+			f[i] -= fe[i]
+		}
+		res.Stations = append(res.Stations, station(e, -f[0], f[1]), station(e+1, f[2], -f[3]))
+	}
+	return res, nil
+}
+
+// TorsionSection holds the section constants for the stresses from
+// torsion: the normalised warping function Wn and warping statical moment
+// Sw at their flange maxima.
+type TorsionSection struct {
+	J      float64 // 10³mm⁴
+	Iw     float64 // 10⁹mm⁶
+	Wn     float64 // mm², at the flange tip (or web junction of a channel)
+	Sw     float64 // mm⁴, in the flange
+	Tf     float64 // Flange thickness (mm)
+	TMax   float64 // Thickest element (mm)
+	Offset float64 // Shear centre from the web centreline, channels only (mm)
+}
+
+// TorsionSectionOf returns the torsion constants of a doubly symmetric
+// I-section or a channel. For channels the shear centre comes from the
+// table's Xo and xL (distances from the centroid and the back of the web)
+// when present.
+func TorsionSectionOf(p models.SteelProperty) (TorsionSection, error) {
+	family := p.Family()
+	if compound.IsCompound(p.Section) {
+		return TorsionSection{}, fmt.Errorf("torsion analysis needs a single I-section or channel, not %s", p.Section)
+	}
+	s := TorsionSection{J: p.J, Iw: p.WarpingConstant(), Tf: p.Tf, TMax: math.Max(p.Tf, p.Tw)}
+	if s.J <= 0 || s.Iw <= 0 {
+		return TorsionSection{}, fmt.Errorf("%s has no J and Iw", p.Section)
+	}
+	h := p.D - p.Tf
+	switch {
+	case family.IsISection() && p.Bf2 == 0:
+		s.Wn = p.Bf * h / 4
+		s.Sw = p.Tf * p.Bf * p.Bf * h / 16
+	case family.IsChannel():
+		b := p.Bf - p.Tw/2
+		s.Offset = 3 * b * b * p.Tf / (6*b*p.Tf + h*p.Tw)
+		if p.Xo > 0 && p.XL > 0 {
+			s.Offset = p.Xo - (p.XL - p.Tw/2)
+		}
+		s.Wn = math.Max(s.Offset, b-s.Offset) * h / 2
+		s.Sw = p.Tf * math.Pow(b-s.Offset, 2) * h / 4
+	default:
+		return TorsionSection{}, fmt.Errorf("torsion analysis needs a doubly symmetric I-section or a channel, not %s", describeFamily(family))
+	}
+	return s, nil
+}
+
+// TorsionStresses are the stresses at a station in MPa.
+type TorsionStresses struct {
+	Warping       float64 // Warping normal stress at the flange tip, B Wn / Iw
+	StVenantShear float64 // St Venant shear stress in the thickest element, Tsv t / J
+	WarpingShear  float64 // Warping shear stress in the flange, Tw Sw / (Iw tf)
+	CombinedShear float64 // Sum of the two shear stress magnitudes
+	NormalToYield float64 // |σw| / fy
+	ShearToYield  float64 // Combined shear / 0.6 fy
+}
+
+// Stresses returns the torsional stresses at a station for yield stress fy.
+func (s TorsionSection) Stresses(st TorsionStation, fy float64) TorsionStresses {
+	iw := s.Iw * 1e9
+	r := TorsionStresses{
+		Warping:       st.Bimoment * 1e9 * s.Wn / iw,
+		StVenantShear: st.StVenant * 1e6 * s.TMax / (s.J * 1e3),
+		WarpingShear:  st.Warping * 1e6 * s.Sw / (iw * s.Tf),
+	}
+	r.CombinedShear = math.Abs(r.StVenantShear) + math.Abs(r.WarpingShear)
+	if fy > 0 {
+		r.NormalToYield = math.Abs(r.Warping) / fy
+		r.ShearToYield = r.CombinedShear / (0.6 * fy)
+	}
+	return r
+}
+
+func describeFamily(f models.Family) string {
+	if f == models.FamilyUnknown {
+		return "an unknown section type"
+	}
+	return string(f)
+}
@@ -0,0 +1,190 @@
+// Package angle transforms equal and unequal angle properties between the
+// geometric and principal axes and checks single angles connected by one
+// leg.
+//
+// The tables follow the Australian convention: x and y are the principal
+// axes (Ix ≥ Iy) and n and p the geometric axes, parallel to the horizontal
+// and vertical legs. The angle is drawn with its long leg vertical, its
+// short leg horizontal and the heel at the bottom left, so nL is the
+// centroid's distance from the back of the vertical leg and pB from the back
+// of the horizontal leg. The x axis lies at α = atan(Tan Alpha)
+// anticlockwise from the n axis.
+package angle
+
+import (
+	"fmt"
+	"math"
+
+	"steel_tables/internal/calc"
+	"steel_tables/internal/models"
+)
+
+// Axis holds the properties about one centroidal axis in table units.
+type Axis struct {
+	Name  string
+	Theta float64 // Angle from the n axis, anticlockwise (degrees)
+	I     float64 // 10⁶mm⁴
+	Z     float64 // Minimum elastic modulus (10³mm³)
+	R     float64 // Radius of gyration (mm)
+	Y     float64 // Distance to the extreme fibre (mm)
+}
+
+// Axes holds an angle's properties about its geometric and principal axes.
+type Axes struct {
+	Alpha float64 // Angle from n to x (degrees)
+	Inp   float64 // Product of inertia about n and p (10⁶mm⁴)
+	N, P  Axis    // Geometric axes
+	X, Y  Axis    // Principal axes
+}
+
+// shape is the outline of an angle relative to its centroid.
+type shape struct {
+	area   float64
+	in, ip float64 // mm⁴
+	inp    float64 // mm⁴
+	alpha  float64 // radians
+	points [][2]float64
+}
+
+func outline(p models.SteelProperty) (shape, error) {
+	if !p.Family().IsAngle() {
+		return shape{}, fmt.Errorf("%s is not an angle", p.Section)
+	}
+	pt, ok := models.Number(p.PT)
+	if !ok || p.NL == 0 || p.PB == 0 || p.In == 0 || p.Ip == 0 {
+		return shape{}, fmt.Errorf("%s has no geometric axis properties", p.Section)
+	}
+	alpha := math.Atan(p.TanAlpha)
+	s := shape{
+		area:  p.Ag,
+		in:    p.In * 1e6,
+		ip:    p.Ip * 1e6,
+		inp:   -(p.Ix - p.Iy) * 1e6 * math.Sin(alpha) * math.Cos(alpha),
+		alpha: alpha,
+	}
+	// The corners that can be extreme fibres: the heel, the ends of the
+	// vertical leg and the ends of the horizontal leg, whose inner corners
+	// are rounded to the toe radius r2.
+	t := p.Tf
+	r2, _ := models.Number(p.R2)
+	left, bottom := -p.NL, -p.PB
+	right := p.Bf - p.NL
+	s.points = [][2]float64{{left, bottom}, {left, pt}, {right, bottom}}
+	for i := 0; i <= 8; i++ {
+		a := float64(i) / 8 * math.Pi / 2
+		du, dv := r2*math.Cos(a), r2*math.Sin(a)
+		s.points = append(s.points,
+			[2]float64{left + t - r2 + du, pt - r2 + dv},
+			[2]float64{right - r2 + du, bottom + t - r2 + dv})
+	}
+	return s, nil
+}
+
+// about returns the properties about the axis at theta radians from n.
+func (s shape) about(name string, theta float64) Axis {
+	sin, cos := math.Sin(theta), math.Cos(theta)
+	i := s.in*cos*cos + s.ip*sin*sin - 2*s.inp*sin*cos
+	y := 0.0
+	for _, pt := range s.points {
+		y = math.Max(y, math.Abs(-pt[0]*sin+pt[1]*cos))
+	}
+	return Axis{
+		Name:  name,
+		Theta: theta * 180 / math.Pi,
+		I:     i / 1e6,
+		Z:     i / y / 1e3,
+		R:     math.Sqrt(i / s.area),
+		Y:     y,
+	}
+}
+
+// GetAxes returns the properties about the geometric and principal axes,
+// computed from the table's In, Ip, Ix, Iy and Tan Alpha.
+func GetAxes(p models.SteelProperty) (Axes, error) {
+	s, err := outline(p)
+	if err != nil {
+		return Axes{}, err
+	}
+	return Axes{
+		Alpha: s.alpha * 180 / math.Pi,
+		Inp:   s.inp / 1e6,
+		N:     s.about("n", 0),
+		P:     s.about("p", math.Pi/2),
+		X:     s.about("x", s.alpha),
+		Y:     s.about("y", s.alpha+math.Pi/2),
+	}, nil
+}
+
+// About returns the properties about the centroidal axis at theta degrees
+// anticlockwise from the n axis.
+func About(p models.SteelProperty, theta float64) (Axis, error) {
+	s, err := outline(p)
+	if err != nil {
+		return Axis{}, err
+	}
+	return s.about(fmt.Sprintf("%g°", theta), theta*math.Pi/180), nil
+}
+
+// Strut holds the compression capacity of a single angle loaded through
+// one leg at each end.
+type Strut struct {
+	Connection  calc.Connection
+	L           float64 // Length between end connections (m)
+	Ra          float64 // Radius of gyration about the geometric axis parallel to the connected leg (mm)
+	Slenderness float64 // L/ra
+	Equivalent  float64 // Equivalent slenderness (Le/r)
+	Fy          float64 // MPa
+	Kf          float64
+	AlphaB      float64
+	LambdaN     float64 // Modified slenderness, Cl. 6.3.3
+	AlphaC      float64
+	PhiNs       float64 // kN
+	PhiNc       float64 // kN
+}
+
+// CheckStrut computes φNc for a single angle strut of length l (m) welded
+// or bolted with at least two bolts through one leg at both ends, with no
+// transverse load, as in a planar truss. The end eccentricity is allowed for
+// by the equivalent slenderness of AISC 360 Cl. E5 about the geometric axis
+// parallel to the connected leg, which then sets λn for the AS 4100
+// Cl. 6.3.3 column curve.
+func CheckStrut(p models.SteelProperty, l float64, c calc.Connection) (Strut, error) {
+	if l <= 0 {
+		return Strut{}, fmt.Errorf("length must be positive")
+	}
+	if _, err := outline(p); err != nil {
+		return Strut{}, err
+	}
+	long, short := math.Max(p.D, p.Bf), math.Min(p.D, p.Bf)
+	s := Strut{Connection: c, L: l, Fy: calc.Yield(p), Kf: p.FormFactor()}
+	switch c {
+	case calc.ConnectionLongLeg:
+		s.Ra = math.Sqrt(p.Ip * 1e6 / p.Ag)
+	case calc.ConnectionShortLeg:
+		if long/short >= 1.7 {
+			return Strut{}, fmt.Errorf("%s has legs in the ratio %.2f: the equivalent slenderness needs less than 1.7 when connected by the short leg", p.Section, long/short)
+		}
+		s.Ra = math.Sqrt(p.In * 1e6 / p.Ag)
+	default:
+		return Strut{}, fmt.Errorf("a single angle strut is connected by its long-leg or short-leg, not %s", c)
+	}
+
+	s.Slenderness = l * 1000 / s.Ra
+	if s.Slenderness <= 80 {
+		s.Equivalent = 72 + 0.75*s.Slenderness
+	} else {
+		s.Equivalent = math.Min(32+1.25*s.Slenderness, 200)
+	}
+	if c == calc.ConnectionShortLeg && long > short {
+		s.Equivalent += 4 * (math.Pow(long/short, 2) - 1)
+		s.Equivalent = math.Max(s.Equivalent, 0.95*l*1000/p.Ry)
+	}
+
+	s.AlphaB, _ = calc.AlphaB(p)
+	s.LambdaN = s.Equivalent * math.Sqrt(s.Kf) * math.Sqrt(s.Fy/250)
+	s.AlphaC = calc.SlendernessReduction(s.LambdaN, s.AlphaB)
+	ns := s.Kf * p.Ag * s.Fy
+	s.PhiNs = calc.PhiCompression * ns / 1e3
+	s.PhiNc = calc.PhiCompression * math.Min(s.AlphaC*ns, ns) / 1e3
+	return s, nil
+}
@@ -0,0 +1,49 @@
+// Package calc implements AS 4100 design capacity calculations for catalog sections.
+//
+// Inputs come from models.SteelProperty in the catalog's table units and are
+// converted to N and mm internally. Results are reported in kN, kNm and m.
+package calc
+
+import (
+	"steel_tables/internal/materials"
+	"steel_tables/internal/models"
+)
+
+// Capacity factors from AS 4100 Table 3.4.
+const (
+	PhiBending     = 0.9
+	PhiCompression = 0.9
+	PhiTension     = 0.9
+	PhiShear       = 0.9
+)
+
+// Elastic and shear moduli of steel in MPa.
+const (
+	E = 200000.0
+	G = 80000.0
+)
+
+// Conversions from catalog table units to mm-based units.
+const (
+	thousand = 1e3 // 10³mm³, 10³mm⁴
+	million  = 1e6 // 10⁶mm⁴
+	billion  = 1e9 // 10⁹mm⁶
+)
+
+// Conversions from N and Nmm to reported units.
+const (
+	toKN  = 1e-3
+	toKNm = 1e-6
+)
+
+// Yield returns the yield stress in MPa used for a row's capacities: the
+// lower of its flange and web values from the materials database.
+func Yield(p models.SteelProperty) float64 {
+	return materials.Of(p).Fy()
+}
+
+// TensileStrength returns fu in MPa for a row from the materials database,
+// or the table's Fu column for grades it does not hold.
+func TensileStrength(p models.SteelProperty) float64 {
+	return materials.Of(p).Fu
+}
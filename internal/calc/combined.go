@@ -0,0 +1,187 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+
+	"steel_tables/internal/models"
+)
+
+// CombinedActions describes the design actions and member geometry for an
+// AS 4100 Section 8 combined actions check. N is positive in compression and
+// negative in tension.
+type CombinedActions struct {
+	N      float64 // Design axial force N* (kN)
+	Mx     float64 // Design major axis moment Mx* (kNm)
+	My     float64 // Design minor axis moment My* (kNm)
+	Lex    float64 // Compression effective length about x (m)
+	Ley    float64 // Compression effective length about y (m)
+	Le     float64 // Lateral-torsional buckling effective length (m)
+	AlphaM float64 // Moment modification factor αm
+	BetaMx float64 // End moment ratio βm about x (-1 to 1)
+	BetaMy float64 // End moment ratio βm about y (-1 to 1)
+}
+
+// InteractionCheck is one design ratio with the clause it comes from.
+type InteractionCheck struct {
+	Clause      string
+	Description string
+	Ratio       float64
+}
+
+// Passes reports whether the ratio is within capacity.
+func (c InteractionCheck) Passes() bool {
+	return c.Ratio <= 1
+}
+
+// CombinedResult holds the reduced capacities and interaction ratios.
+type CombinedResult struct {
+	PhiN   float64 // φNs in compression or φNt in tension (kN)
+	PhiNcx float64 // Member compression capacity about x (kN)
+	PhiNcy float64 // Member compression capacity about y (kN)
+	PhiMrx float64 // Reduced section moment capacity about x, Cl. 8.3.2 (kNm)
+	PhiMry float64 // Reduced section moment capacity about y, Cl. 8.3.3 (kNm)
+	PhiMix float64 // In-plane member capacity about x, Cl. 8.4.2 (kNm)
+	PhiMiy float64 // In-plane member capacity about y, Cl. 8.4.2 (kNm)
+	PhiMox float64 // Out-of-plane member capacity, Cl. 8.4.4 (kNm)
+
+	Checks    []InteractionCheck
+	Governing InteractionCheck
+}
+
+// CheckCombined performs the AS 4100 Section 8 section and member checks for
+// a beam-column.
+func CheckCombined(p models.SteelProperty, a CombinedActions) (CombinedResult, error) {
+	family := p.Family()
+	if family.IsAngle() || family.IsTee() || family == models.FamilyUnknown {
+		return CombinedResult{}, fmt.Errorf("combined actions check supports I-sections, channels and hollow sections, not %s", describeFamily(family))
+	}
+	if a.AlphaM <= 0 {
+		a.AlphaM = 1
+	}
+	if a.Le <= 0 {
+		a.Le = a.Ley
+	}
+
+	sc := Section(p)
+	n := math.Abs(a.N)
+	mx := math.Abs(a.Mx)
+	my := math.Abs(a.My)
+	compression := a.N > 0
+
+	var res CombinedResult
+	var comp MemberCompression
+	if compression {
+		var err error
+		comp, err = MemberCompressionCapacity(p, a.Lex, a.Ley)
+		if err != nil {
+			return CombinedResult{}, err
+		}
+		res.PhiN = sc.PhiNs
+		res.PhiNcx = comp.X.PhiNc
+		res.PhiNcy = comp.Y.PhiNc
+	} else {
+		res.PhiN = sc.PhiNt
+	}
+
+	// Section capacity, Cl. 8.3.
+	ratioN := n / res.PhiN
+	enhanced := sc.Kf >= 1 && (family.IsISection() || family == models.FamilyRHS || family == models.FamilySHS)
+	res.PhiMrx = sc.PhiMsx * (1 - ratioN)
+	res.PhiMry = sc.PhiMsy * (1 - ratioN)
+	if enhanced && isCompact(p.CNS) {
+		res.PhiMrx = math.Min(sc.PhiMsx, 1.18*sc.PhiMsx*(1-ratioN))
+	}
+	if enhanced && isCompact(p.CNS2) {
+		if family.IsISection() {
+			res.PhiMry = math.Min(sc.PhiMsy, 1.19*sc.PhiMsy*(1-ratioN*ratioN))
+		} else {
+			res.PhiMry = math.Min(sc.PhiMsy, 1.18*sc.PhiMsy*(1-ratioN))
+		}
+	}
+	res.PhiMrx = math.Max(res.PhiMrx, 0)
+	res.PhiMry = math.Max(res.PhiMry, 0)
+
+	add := func(clause, description string, demand, capacity float64) {
+		res.Checks = append(res.Checks, InteractionCheck{clause, description, Ratio(demand, capacity)})
+	}
+
+	add("8.3.1", "Section axial", n, res.PhiN)
+	add("8.3.2", "Section moment, x axis (Mx*/φMrx)", mx, res.PhiMrx)
+	add("8.3.3", "Section moment, y axis (My*/φMry)", my, res.PhiMry)
+	biaxialSection := ratioN + Ratio(mx, sc.PhiMsx) + Ratio(my, sc.PhiMsy)
+	if enhanced && isCompact(p.CNS) && isCompact(p.CNS2) && family.IsISection() {
+		gamma := math.Min(2, 1.4+ratioN)
+		biaxialSection = math.Pow(Ratio(mx, res.PhiMrx), gamma) + math.Pow(Ratio(my, res.PhiMry), gamma)
+	}
+	res.Checks = append(res.Checks, InteractionCheck{"8.3.4", "Section biaxial bending", biaxialSection})
+
+	// Member capacity, Cl. 8.4.
+	phiMbx := sc.PhiMsx
+	if family.IsISection() || family.IsChannel() {
+		mb, err := MemberMomentCapacity(p, a.Le, a.AlphaM)
+		if err != nil {
+			return CombinedResult{}, err
+		}
+		phiMbx = mb.PhiMbx
+	}
+
+	if compression {
+		add("6.3", "Member axial compression (N*/φNc)", n, math.Min(res.PhiNcx, res.PhiNcy))
+		res.PhiMix = inPlaneCapacity(sc.PhiMsx, res.PhiMrx, n, res.PhiNcx, a.BetaMx, enhanced && isCompact(p.CNS))
+		res.PhiMiy = inPlaneCapacity(sc.PhiMsy, res.PhiMry, n, res.PhiNcy, a.BetaMy, enhanced && isCompact(p.CNS2))
+		res.PhiMox = math.Max(0, math.Min(phiMbx*(1-n/res.PhiNcy), res.PhiMrx))
+	} else {
+		// Members in tension, Cl. 8.4.2.4 and 8.4.4.2.
+		res.PhiMix = res.PhiMrx
+		res.PhiMiy = res.PhiMry
+		res.PhiMox = math.Min(phiMbx*(1+n/res.PhiN), res.PhiMrx)
+	}
+
+	add("8.4.2", "In-plane member, x axis (Mx*/φMix)", mx, res.PhiMix)
+	add("8.4.2", "In-plane member, y axis (My*/φMiy)", my, res.PhiMiy)
+	add("8.4.4", "Out-of-plane member (Mx*/φMox)", mx, res.PhiMox)
+	phiMcx := math.Min(res.PhiMix, res.PhiMox)
+	biaxialMember := math.Pow(Ratio(mx, phiMcx), 1.4) + math.Pow(Ratio(my, res.PhiMiy), 1.4)
+	res.Checks = append(res.Checks, InteractionCheck{"8.4.5", "Member biaxial bending", biaxialMember})
+
+	for _, c := range res.Checks {
+		if c.Ratio > res.Governing.Ratio || res.Governing.Clause == "" {
+			res.Governing = c
+		}
+	}
+	return res, nil
+}
+
+// inPlaneCapacity applies Cl. 8.4.2.2. The βm form applies to compact
+// sections with kf = 1; otherwise Mi = Ms (1 - N*/φNc).
+func inPlaneCapacity(phiMs, phiMr, n, phiNc, betaM float64, compact bool) float64 {
+	if phiNc <= 0 || n >= phiNc {
+		return 0
+	}
+	mi := phiMs * (1 - n/phiNc)
+	if compact {
+		b := (1 + betaM) / 2
+		mi = phiMs * ((1-math.Pow(b, 3))*(1-n/phiNc) + 1.18*math.Pow(b, 3)*math.Sqrt(1-n/phiNc))
+		mi = math.Min(mi, phiMr)
+	}
+	return math.Max(mi, 0)
+}
+
+// isCompact reports whether a C,N,S classification value is "C".
+func isCompact(cns interface{}) bool {
+	s, ok := cns.(string)
+	return ok && s == "C"
+}
+
+// Ratio returns |demand|/capacity, treating zero demand as zero and zero
+// capacity under load as infinite.
+func Ratio(demand, capacity float64) float64 {
+	if demand == 0 {
+		return 0
+	}
+	if capacity <= 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(demand) / capacity
+}
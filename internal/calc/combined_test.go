@@ -0,0 +1,28 @@
+package calc
+
+import "testing"
+
+// φMix for a compact section must rise steadily from single curvature
+// (βm = -1) to double curvature (βm = +1), where it reaches φMr.
+func TestInPlaneCapacityRisesWithBetaM(t *testing.T) {
+	const phiMs, phiMr, n, phiNc = 600.0, 480.0, 2000.0, 5000.0
+	single := phiMs * (1 - n/phiNc)
+	prev := 0.0
+	for i := 0; i <= 20; i++ {
+		betaM := -1 + float64(i)/10
+		mi := inPlaneCapacity(phiMs, phiMr, n, phiNc, betaM, true)
+		if i == 0 && mi != single {
+			t.Errorf("βm = -1: φMix = %.1f, want φMs(1 - N*/φNc) = %.1f", mi, single)
+		}
+		if mi < prev {
+			t.Errorf("βm = %.1f: φMix = %.1f fell from %.1f", betaM, mi, prev)
+		}
+		if mi > phiMr {
+			t.Errorf("βm = %.1f: φMix = %.1f exceeds φMr = %.1f", betaM, mi, phiMr)
+		}
+		prev = mi
+	}
+	if prev != phiMr {
+		t.Errorf("βm = +1: φMix = %.1f, want φMr = %.1f", prev, phiMr)
+	}
+}
@@ -0,0 +1,150 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+
+	"steel_tables/internal/models"
+)
+
+// AxisCompression holds the member compression capacity about one axis.
+type AxisCompression struct {
+	Le      float64 // Effective length (m)
+	LambdaN float64 // Modified slenderness λn, Cl. 6.3.3
+	AlphaC  float64 // Slenderness reduction factor αc
+	PhiNc   float64 // Design member capacity (kN)
+}
+
+// MemberCompression holds the AS 4100 Section 6 member axial compression
+// capacities about both axes.
+type MemberCompression struct {
+	Fy           float64 // Yield stress (MPa)
+	Kf           float64 // Form factor
+	AlphaB       float64 // Member section constant αb
+	AlphaBSource string  // Where αb came from
+	PhiNs        float64 // Design section capacity (kN)
+	X            AxisCompression
+	Y            AxisCompression
+}
+
+// MemberCompressionCapacity computes φNc about the x and y axes for
+// effective lengths lex and ley in m.
+func MemberCompressionCapacity(p models.SteelProperty, lex, ley float64) (MemberCompression, error) {
+	if lex <= 0 || ley <= 0 {
+		return MemberCompression{}, fmt.Errorf("effective lengths must be positive")
+	}
+	if p.Rx <= 0 || p.Ry <= 0 {
+		return MemberCompression{}, fmt.Errorf("%s has no radius of gyration", p.Section)
+	}
+
+	alphaB, source := AlphaB(p)
+	c := MemberCompression{
+		Fy:           Yield(p),
+		Kf:           p.FormFactor(),
+		AlphaB:       alphaB,
+		AlphaBSource: source,
+	}
+	ns := c.Kf * p.Ag * c.Fy
+	c.PhiNs = PhiCompression * ns * toKN
+	c.X = axisCompression(lex, p.Rx, c.Kf, c.Fy, alphaB, ns)
+	c.Y = axisCompression(ley, p.Ry, c.Kf, c.Fy, alphaB, ns)
+	return c, nil
+}
+
+// MemberCompressionTable computes φNc at each effective length, using the
+// same length about both axes.
+func MemberCompressionTable(p models.SteelProperty, lengths []float64) ([]MemberCompression, error) {
+	results := make([]MemberCompression, 0, len(lengths))
+	for _, le := range lengths {
+		c, err := MemberCompressionCapacity(p, le, le)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, c)
+	}
+	return results, nil
+}
+
+// axisCompression applies Cl. 6.3.3 for one axis. le is in m, r in mm and
+// ns in N.
+func axisCompression(le, r, kf, fy, alphaB, ns float64) AxisCompression {
+	lambdaN := (le * 1000 / r) * math.Sqrt(kf) * math.Sqrt(fy/250)
+	alphaC := SlendernessReduction(lambdaN, alphaB)
+	return AxisCompression{
+		Le:      le,
+		LambdaN: lambdaN,
+		AlphaC:  alphaC,
+		PhiNc:   PhiCompression * math.Min(alphaC*ns, ns) * toKN,
+	}
+}
+
+// SlendernessReduction returns αc for a modified slenderness λn and member
+// section constant αb (AS 4100 Cl. 6.3.3).
+func SlendernessReduction(lambdaN, alphaB float64) float64 {
+	alphaA := 2100 * (lambdaN - 13.5) / (lambdaN*lambdaN - 15.3*lambdaN + 2050)
+	lambda := lambdaN + alphaA*alphaB
+	eta := math.Max(0, 0.00326*(lambda-13.5))
+	if lambda <= 0 {
+		return 1
+	}
+	ratio := math.Pow(lambda/90, 2)
+	xi := (ratio + 1 + eta) / (2 * ratio)
+	alphaC := xi * (1 - math.Sqrt(1-math.Pow(90/(xi*lambda), 2)))
+	return math.Min(alphaC, 1)
+}
+
+// AlphaB returns the member section constant αb for a row and a note on its
+// source. The table's αb column is used when present; otherwise it is picked
+// from AS 4100 Table 6.3.3 using the family, residual stress classification
+// (HR hot-rolled, HW heavily welded, LW lightly welded, CF cold-formed), kf
+// and flange thickness.
+func AlphaB(p models.SteelProperty) (float64, string) {
+	if ab, ok := p.CompressionAlphaB(); ok {
+		return ab, "table"
+	}
+
+	family := p.Family()
+	residual := p.Residual
+	if residual == "" && family.IsHollow() {
+		// Catalog hollow sections are cold-formed, non-stress-relieved.
+		residual = "CF"
+	}
+	note := func(rule string) string {
+		if residual == "" {
+			return "Table 6.3.3, " + rule
+		}
+		return fmt.Sprintf("Table 6.3.3, %s (%s)", rule, residual)
+	}
+
+	if p.FormFactor() >= 1 {
+		switch {
+		case family.IsHollow() && residual == "CF":
+			return -0.5, note("cold-formed hollow, kf = 1")
+		case family.IsHollow():
+			return -1.0, note("hot-formed hollow, kf = 1")
+		case family.IsISection() && residual == "HR" && p.Tf <= 40:
+			return 0, note("hot-rolled I, tf ≤ 40 mm, kf = 1")
+		case family.IsISection() && residual == "LW" && p.Tf <= 40:
+			return 0, note("lightly welded I, tf ≤ 40 mm, kf = 1")
+		case family.IsISection() && residual != "HW" && p.Tf > 40:
+			return 0.5, note("I-section, tf > 40 mm, kf = 1")
+		case family.IsISection() && p.Tf <= 40:
+			return 0.5, note("heavily welded I, tf ≤ 40 mm, kf = 1")
+		case family.IsChannel() || family.IsAngle():
+			return 0.5, note("channel or angle, kf = 1")
+		}
+		return 1.0, note("other sections, kf = 1")
+	}
+
+	switch {
+	case family.IsHollow() && residual == "CF":
+		return 0, note("cold-formed hollow, kf < 1")
+	case family.IsHollow():
+		return -0.5, note("hot-formed hollow, kf < 1")
+	case family.IsISection() && residual == "HR":
+		return 0.5, note("hot-rolled I, kf < 1")
+	case family.IsWelded():
+		return 0.5, note("welded I with flame-cut flanges, kf < 1")
+	}
+	return 1.0, note("other sections, kf < 1")
+}
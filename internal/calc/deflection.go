@@ -0,0 +1,192 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Support is the end restraint of a single-span beam.
+type Support int
+
+// Beam support conditions.
+const (
+	SimplySupported Support = iota
+	Cantilever
+	FixedEnds
+)
+
+// deflectionSteps is the number of intervals sampled along the span when
+// searching for the maximum deflection.
+const deflectionSteps = 400
+
+// ParseSupport parses a support name: ss, cantilever or fixed.
+func ParseSupport(value string) (Support, error) {
+	switch strings.ToLower(value) {
+	case "ss", "simple", "simply-supported", "pinned":
+		return SimplySupported, nil
+	case "c", "cant", "cantilever":
+		return Cantilever, nil
+	case "f", "fixed", "fixed-end", "fixed-ends":
+		return FixedEnds, nil
+	}
+	return SimplySupported, fmt.Errorf("unknown support '%s' (want ss, cantilever or fixed)", value)
+}
+
+func (s Support) String() string {
+	switch s {
+	case Cantilever:
+		return "cantilever"
+	case FixedEnds:
+		return "fixed ends"
+	}
+	return "simply supported"
+}
+
+// PointLoad is a concentrated load P (kN) at distance A (m) from the left
+// end, or from the fixed end of a cantilever.
+type PointLoad struct {
+	P float64
+	A float64
+}
+
+// ParsePointLoad parses "P@A", e.g. "20@3.5" for 20 kN at 3.5 m.
+func ParsePointLoad(value string) (PointLoad, error) {
+	parts := strings.SplitN(value, "@", 2)
+	if len(parts) != 2 {
+		return PointLoad{}, fmt.Errorf("invalid point load '%s' (want P@A, e.g. 20@3.5)", value)
+	}
+	p, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	a, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err1 != nil || err2 != nil {
+		return PointLoad{}, fmt.Errorf("invalid point load '%s' (want P@A, e.g. 20@3.5)", value)
+	}
+	return PointLoad{P: p, A: a}, nil
+}
+
+// BeamLoading describes a single-span beam and its serviceability loads.
+type BeamLoading struct {
+	Support Support
+	Span    float64     // Span or cantilever length (m)
+	UDL     float64     // Uniformly distributed load (kN/m)
+	Points  []PointLoad // Concentrated loads
+}
+
+// Validate checks the span and load positions.
+func (b BeamLoading) Validate() error {
+	if b.Span <= 0 {
+		return fmt.Errorf("span must be positive")
+	}
+	for _, pl := range b.Points {
+		if pl.A < 0 || pl.A > b.Span {
+			return fmt.Errorf("point load at %.2f m is outside the %.2f m span", pl.A, b.Span)
+		}
+	}
+	return nil
+}
+
+// Deflection is the maximum deflection of a beam and the serviceability check.
+type Deflection struct {
+	Max        float64 // Maximum deflection (mm)
+	At         float64 // Location of the maximum from the left or fixed end (m)
+	Allowed    float64 // Allowed deflection span/limit (mm)
+	Ratio      float64 // Max/Allowed
+	IxRequired float64 // Minimum second moment of area to meet the limit (10⁶mm⁴)
+}
+
+// Passes reports whether the deflection is within the limit.
+func (d Deflection) Passes() bool {
+	return d.Ratio <= 1
+}
+
+// BeamDeflection computes the maximum deflection of the beam for a second
+// moment of area i (10⁶mm⁴) and checks it against span/limit. For a
+// cantilever the limit applies to the cantilever length.
+func BeamDeflection(b BeamLoading, i, limit float64) (Deflection, error) {
+	if err := b.Validate(); err != nil {
+		return Deflection{}, err
+	}
+	if limit <= 0 {
+		return Deflection{}, fmt.Errorf("deflection limit must be positive")
+	}
+
+	// Deflection is proportional to 1/EI, so find the shape once for EI = 1.
+	unit, at := maxUnitDeflection(b)
+	d := Deflection{
+		At:         at,
+		Allowed:    b.Span * 1000 / limit,
+		IxRequired: unit / E / (b.Span * 1000 / limit) / million,
+	}
+	if i > 0 {
+		d.Max = unit / (E * i * million)
+		d.Ratio = d.Max / d.Allowed
+	}
+	return d, nil
+}
+
+// RequiredIx returns the minimum second moment of area (10⁶mm⁴) for the beam
+// to meet span/limit.
+func RequiredIx(b BeamLoading, limit float64) (float64, error) {
+	d, err := BeamDeflection(b, 0, limit)
+	return d.IxRequired, err
+}
+
+// maxUnitDeflection returns the largest |EI·y| in N·mm³ and its position in m.
+func maxUnitDeflection(b BeamLoading) (float64, float64) {
+	l := b.Span * 1000
+	maxY, maxX := 0.0, 0.0
+	for step := 0; step <= deflectionSteps; step++ {
+		x := l * float64(step) / deflectionSteps
+		y := math.Abs(unitDeflectionAt(b, x))
+		if y > maxY {
+			maxY, maxX = y, x
+		}
+	}
+	return maxY, maxX / 1000
+}
+
+// unitDeflectionAt returns EI·y at x (mm) by superposing standard cases.
+// w in kN/m equals N/mm; P in kN is converted to N.
+func unitDeflectionAt(b BeamLoading, x float64) float64 {
+	l := b.Span * 1000
+	w := b.UDL
+	y := 0.0
+
+	switch b.Support {
+	case SimplySupported:
+		y += w * x * (l*l*l - 2*l*x*x + x*x*x) / 24
+		for _, pl := range b.Points {
+			p, a := pl.P*1000, pl.A*1000
+			bb := l - a
+			if x <= a {
+				y += p * bb * x * (l*l - bb*bb - x*x) / (6 * l)
+			} else {
+				y += p * a * (l - x) * (2*l*x - x*x - a*a) / (6 * l)
+			}
+		}
+	case Cantilever:
+		y += w * x * x * (6*l*l - 4*l*x + x*x) / 24
+		for _, pl := range b.Points {
+			p, a := pl.P*1000, pl.A*1000
+			if x <= a {
+				y += p * x * x * (3*a - x) / 6
+			} else {
+				y += p * a * a * (3*x - a) / 6
+			}
+		}
+	case FixedEnds:
+		y += w * x * x * (l - x) * (l - x) / 24
+		for _, pl := range b.Points {
+			p, a := pl.P*1000, pl.A*1000
+			bb := l - a
+			if x <= a {
+				y += p * bb * bb * x * x * (3*a*l - 3*a*x - bb*x) / (6 * l * l * l)
+			} else {
+				xr := l - x
+				y += p * a * a * xr * xr * (3*bb*l - 3*bb*xr - a*xr) / (6 * l * l * l)
+			}
+		}
+	}
+	return y
+}
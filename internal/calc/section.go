@@ -0,0 +1,91 @@
+package calc
+
+import (
+	"math"
+
+	"steel_tables/internal/materials"
+	"steel_tables/internal/models"
+)
+
+// SectionCapacity holds the AS 4100 section capacities for a row.
+type SectionCapacity struct {
+	Fy  float64 // Yield stress used for section capacity (MPa)
+	Fyw float64 // Web yield stress used for shear (MPa)
+	Fu  float64 // Tensile strength (MPa)
+	Kf  float64 // Form factor
+
+	PhiMsx float64 // Major axis section moment capacity, Cl. 5.2 (kNm)
+	PhiMsy float64 // Minor axis section moment capacity, Cl. 5.2 (kNm)
+	PhiNs  float64 // Axial compression section capacity, Cl. 6.2 (kN)
+	PhiNt  float64 // Axial tension section capacity, Cl. 7.2 (kN)
+	PhiVv  float64 // Major axis shear capacity, Cl. 5.11 (kN)
+
+	Aw          float64 // Shear area (mm²)
+	WebSlender  float64 // Web slenderness dp/tw
+	ShearYields bool    // True if the web reaches shear yield (no shear buckling)
+}
+
+// Section computes section capacities for a catalog row. Tension assumes the
+// gross area is effective (An = Ag, kt = 1).
+func Section(p models.SteelProperty) SectionCapacity {
+	m := materials.Of(p)
+	c := SectionCapacity{
+		Fy:  m.Fy(),
+		Fyw: m.Web,
+		Fu:  m.Fu,
+		Kf:  p.FormFactor(),
+	}
+	if c.Fyw == 0 {
+		c.Fyw = c.Fy
+	}
+
+	c.PhiMsx = PhiBending * c.Fy * p.Zex * thousand * toKNm
+	c.PhiMsy = PhiBending * c.Fy * p.MinorZe() * thousand * toKNm
+	c.PhiNs = PhiCompression * c.Kf * p.Ag * c.Fy * toKN
+
+	nt := math.Min(p.Ag*c.Fy, 0.85*p.Ag*c.Fu)
+	c.PhiNt = PhiTension * nt * toKN
+
+	vv, aw, slender, yields := shearCapacity(p, c.Fyw)
+	c.PhiVv = PhiShear * vv * toKN
+	c.Aw = aw
+	c.WebSlender = slender
+	c.ShearYields = yields
+	return c
+}
+
+// shearCapacity returns the nominal major axis shear capacity Vv in N, the
+// shear area, the web slenderness and whether the web yields in shear
+// (AS 4100 Cl. 5.11.2 to 5.11.5).
+func shearCapacity(p models.SteelProperty, fyw float64) (vv, aw, slender float64, yields bool) {
+	family := p.Family()
+	if family == models.FamilyCHS {
+		// Cl. 5.11.4 for circular hollow sections.
+		return 0.36 * fyw * p.Ag, p.Ag, 0, true
+	}
+
+	dp := p.D1
+	tw := p.Tw
+	limit := 82.0
+	switch {
+	case family.IsHollow():
+		aw = 2 * dp * tw
+		limit = 64
+	case family.IsWelded():
+		aw = dp * tw
+	default:
+		aw = p.D * tw
+	}
+	if tw <= 0 || dp <= 0 {
+		return 0, aw, 0, false
+	}
+
+	vw := 0.6 * fyw * aw
+	slender = dp / tw
+	lambda := slender * math.Sqrt(fyw/250)
+	if lambda <= limit {
+		return vw, aw, slender, true
+	}
+	alphaV := math.Min(1, math.Pow(limit/lambda, 2))
+	return alphaV * vw, aw, slender, false
+}
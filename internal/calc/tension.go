@@ -0,0 +1,183 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/models"
+)
+
+// Connection describes how the end of a tension member is connected, which
+// sets the correction factor kt of AS 4100 Table 7.3.2.
+type Connection string
+
+const (
+	ConnectionUniform  Connection = "uniform"   // Every element connected, kt = 1
+	ConnectionLongLeg  Connection = "long-leg"  // Angle by its long (or either equal) leg
+	ConnectionShortLeg Connection = "short-leg" // Angle by its short leg
+	ConnectionWeb      Connection = "web"       // Channel by its web
+)
+
+// ParseConnection converts a command-line value to a Connection.
+func ParseConnection(value string) (Connection, error) {
+	switch c := Connection(strings.ToLower(value)); c {
+	case ConnectionUniform, ConnectionLongLeg, ConnectionShortLeg, ConnectionWeb:
+		return c, nil
+	}
+	return "", fmt.Errorf("invalid connection '%s' (want uniform, long-leg, short-leg or web)", value)
+}
+
+// CorrectionFactor returns kt for a section connected as c (AS 4100
+// Cl. 7.3.2): 0.75 for an unequal angle by its short leg, 0.85 for other
+// angles by one leg and channels by the web, and 1 when every element is
+// connected.
+func CorrectionFactor(p models.SteelProperty, c Connection) (float64, error) {
+	family := p.Family()
+	switch c {
+	case ConnectionUniform:
+		return 1, nil
+	case ConnectionLongLeg, ConnectionShortLeg:
+		if !family.IsAngle() {
+			return 0, fmt.Errorf("a %s connection needs an angle, not %s", c, describeFamily(family))
+		}
+		if c == ConnectionShortLeg && p.Bf < p.D {
+			return 0.75, nil
+		}
+		return 0.85, nil
+	case ConnectionWeb:
+		if !family.IsChannel() {
+			return 0, fmt.Errorf("a web connection needs a channel, not %s", describeFamily(family))
+		}
+		return 0.85, nil
+	}
+	return 0, fmt.Errorf("invalid connection '%s'", c)
+}
+
+// Hole is a bolt hole located by its gauge across the element and its
+// position along the member, both in mm from any fixed reference.
+type Hole struct {
+	Gauge float64
+	Pitch float64
+}
+
+// ParseHole parses a hole in the form "G@S" or "G" (gauge G at position S
+// along the member, default 0), both in mm.
+func ParseHole(value string) (Hole, error) {
+	g, s, staggered := strings.Cut(value, "@")
+	gauge, err := strconv.ParseFloat(strings.TrimSpace(g), 64)
+	if err != nil {
+		return Hole{}, fmt.Errorf("invalid hole '%s' (want gauge@position in mm)", value)
+	}
+	h := Hole{Gauge: gauge}
+	if staggered {
+		if h.Pitch, err = strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+			return Hole{}, fmt.Errorf("invalid hole '%s' (want gauge@position in mm)", value)
+		}
+	}
+	return h, nil
+}
+
+// HolePattern is a group of holes of one diameter through an element of one
+// thickness, repeated Count times across the section (e.g. 2 for matching
+// holes in both flanges).
+type HolePattern struct {
+	Diameter  float64 // Hole diameter (mm)
+	Thickness float64 // Thickness of the holed element (mm)
+	Holes     []Hole
+	Count     int
+}
+
+// NetTension holds the AS 4100 Section 7 tension capacity of a member with
+// holes.
+type NetTension struct {
+	Fy float64 // Yield stress (MPa)
+	Fu float64 // Tensile strength (MPa)
+	Kt float64 // Correction factor for the end connection
+
+	Deduction float64 // Area deducted for the critical chain of holes (mm²)
+	Chain     []Hole  // Holes on the critical chain, across the element
+	An        float64 // Net area (mm²)
+
+	NtYield    float64 // Ag fy (kN)
+	NtFracture float64 // 0.85 kt An fu (kN)
+	PhiNt      float64 // Design section capacity, Cl. 7.2 (kN)
+}
+
+// NetSectionTension computes the net area by the AS 4100 Cl. 7.3.1 chain
+// rule and φNt for a member with holes connected as c. Every chain that
+// crosses the element taking at most one hole per gauge line is tried; each
+// hole deducts dh t and each staggered step sp² t / 4sg is added back. The
+// chain with the largest deduction governs.
+func NetSectionTension(p models.SteelProperty, holes HolePattern, c Connection) (NetTension, error) {
+	kt, err := CorrectionFactor(p, c)
+	if err != nil {
+		return NetTension{}, err
+	}
+	if len(holes.Holes) > 0 && (holes.Diameter <= 0 || holes.Thickness <= 0) {
+		return NetTension{}, fmt.Errorf("hole diameter and element thickness must be positive")
+	}
+	count := holes.Count
+	if count <= 0 {
+		count = 1
+	}
+
+	t := NetTension{
+		Fy: Yield(p),
+		Fu: TensileStrength(p),
+		Kt: kt,
+	}
+	deduction, chain := criticalChain(holes)
+	t.Deduction = deduction * float64(count)
+	t.Chain = chain
+	t.An = p.Ag - t.Deduction
+	if t.An <= 0 {
+		return NetTension{}, fmt.Errorf("holes remove the whole %.0f mm² of %s", p.Ag, p.Section)
+	}
+
+	t.NtYield = p.Ag * t.Fy * toKN
+	t.NtFracture = 0.85 * kt * t.An * t.Fu * toKN
+	t.PhiNt = PhiTension * math.Min(t.NtYield, t.NtFracture)
+	return t, nil
+}
+
+// criticalChain returns the largest deduction in mm² of any chain of holes
+// and the holes on it in order of gauge.
+func criticalChain(h HolePattern) (float64, []Hole) {
+	holes := append([]Hole(nil), h.Holes...)
+	sort.SliceStable(holes, func(i, j int) bool { return holes[i].Gauge < holes[j].Gauge })
+
+	// best[i] is the largest deduction of a chain ending at hole i, and
+	// prev[i] the hole before it.
+	hole := h.Diameter * h.Thickness
+	best := make([]float64, len(holes))
+	prev := make([]int, len(holes))
+	end := -1
+	for i, hi := range holes {
+		best[i], prev[i] = hole, -1
+		for j := 0; j < i; j++ {
+			sg := hi.Gauge - holes[j].Gauge
+			if sg <= 0 {
+				continue
+			}
+			sp := hi.Pitch - holes[j].Pitch
+			if d := best[j] + hole - sp*sp*h.Thickness/(4*sg); d > best[i] {
+				best[i], prev[i] = d, j
+			}
+		}
+		if end == -1 || best[i] > best[end] {
+			end = i
+		}
+	}
+	if end == -1 {
+		return 0, nil
+	}
+
+	var chain []Hole
+	for i := end; i != -1; i = prev[i] {
+		chain = append([]Hole{holes[i]}, chain...)
+	}
+	return best[end], chain
+}
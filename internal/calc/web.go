@@ -0,0 +1,96 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+
+	"steel_tables/internal/models"
+)
+
+// WebLoad describes the shear and concentrated load applied to a web.
+type WebLoad struct {
+	V       float64 // Design shear force V* (kN)
+	R       float64 // Design bearing force R* (kN)
+	Bearing float64 // Stiff bearing length bs (mm)
+	AtEnd   bool    // Load applied at the member end rather than within the span
+}
+
+// WebCheck holds the AS 4100 web shear and bearing results for a section.
+type WebCheck struct {
+	PhiVv   float64 // Shear capacity, Cl. 5.11 (kN)
+	Bbf     float64 // Bearing yield width, Cl. 5.13.3 (mm)
+	PhiRby  float64 // Bearing yield capacity, Cl. 5.13.3 (kN)
+	Bb      float64 // Bearing buckling width, Cl. 5.13.4 (mm)
+	LambdaN float64 // Web slenderness for bearing buckling
+	AlphaC  float64 // Slenderness reduction factor for bearing buckling
+	PhiRbb  float64 // Bearing buckling capacity, Cl. 5.13.4 (kN)
+	PhiRb   float64 // Governing bearing capacity (kN)
+
+	ShearRatio   float64 // V*/φVv
+	BearingRatio float64 // R*/φRb
+
+	DoublerRequired   bool // Web needs a doubler plate for shear
+	StiffenerRequired bool // Web needs a load-bearing stiffener
+
+	// Doubler and Stiffener are the table's Doubler and Stiffener values.
+	Doubler   string
+	Stiffener string
+}
+
+// CheckWeb computes the web shear capacity and the bearing yield and buckling
+// capacities under a stiff bearing length, and reports whether a doubler plate
+// or stiffener is required. Only I-sections and channels are supported.
+func CheckWeb(p models.SteelProperty, load WebLoad) (WebCheck, error) {
+	family := p.Family()
+	if !family.IsISection() && !family.IsChannel() {
+		return WebCheck{}, fmt.Errorf("web checks need an I-section or channel, not %s", describeFamily(family))
+	}
+	if p.Tw <= 0 || p.D1 <= 0 {
+		return WebCheck{}, fmt.Errorf("%s has no web dimensions", p.Section)
+	}
+
+	sc := Section(p)
+	fyw := sc.Fyw
+	c := WebCheck{
+		PhiVv:     sc.PhiVv,
+		Doubler:   tableValue(p.Doubler),
+		Stiffener: tableValue(p.Stiffener),
+	}
+
+	// Bearing yield: 1:2.5 dispersion through the flange and root.
+	if load.AtEnd {
+		c.Bbf = load.Bearing + 2.5*p.Tf
+	} else {
+		c.Bbf = load.Bearing + 5*p.Tf
+	}
+	c.PhiRby = PhiShear * 1.25 * c.Bbf * p.Tw * fyw * toKN
+
+	// Bearing buckling: 1:1 dispersion to the web mid-depth, treating the web
+	// as a column with le = d1, αb = 0.5 and kf = 1.
+	if load.AtEnd {
+		c.Bb = c.Bbf + p.D1/2
+	} else {
+		c.Bb = c.Bbf + p.D1
+	}
+	c.LambdaN = 2.5 * (p.D1 / p.Tw) * math.Sqrt(fyw/250)
+	c.AlphaC = SlendernessReduction(c.LambdaN, 0.5)
+	c.PhiRbb = PhiShear * c.AlphaC * c.Bb * p.Tw * fyw * toKN
+
+	c.PhiRb = math.Min(c.PhiRby, c.PhiRbb)
+	c.ShearRatio = Ratio(math.Abs(load.V), c.PhiVv)
+	c.BearingRatio = Ratio(math.Abs(load.R), c.PhiRb)
+	c.DoublerRequired = c.ShearRatio > 1
+	c.StiffenerRequired = c.BearingRatio > 1
+	return c, nil
+}
+
+// tableValue formats a loosely typed table value, or "-" when blank.
+func tableValue(value interface{}) string {
+	if f, ok := models.Number(value); ok {
+		return fmt.Sprintf("%g", f)
+	}
+	if s, ok := value.(string); ok && s != "" {
+		return s
+	}
+	return "-"
+}
@@ -0,0 +1,244 @@
+// Package catalog loads steel section tables from the data directory.
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"steel_tables/internal/config"
+	"steel_tables/internal/girder"
+	"steel_tables/internal/models"
+	"steel_tables/internal/tee"
+)
+
+const fileSuffix = "_PROPS.json"
+
+// UserTable is the table that holds sections saved by the user.
+const UserTable = "USER"
+
+// TableFile converts a table name such as "ub350" into its data filename.
+func TableFile(tableName string) string {
+	name := strings.ToUpper(strings.TrimSuffix(tableName, ".json"))
+	name = strings.TrimSuffix(name, "_PROPS")
+	return name + fileSuffix
+}
+
+// TableName converts a data filename back into its table name.
+func TableName(filename string) string {
+	return strings.TrimSuffix(filename, fileSuffix)
+}
+
+// Exists reports whether the named table is present in the data directory
+// or is a tee table derived from one.
+func Exists(tableName string) bool {
+	if _, ok := teeParent(tableName); ok {
+		return true
+	}
+	return config.FileExists(TableFile(tableName))
+}
+
+// Tables returns the names of all tables in the data directory and the tee
+// tables derived from them, sorted.
+func Tables() ([]string, error) {
+	names, err := fileTables()
+	if err != nil {
+		return nil, err
+	}
+	tees, err := TeeTables()
+	if err != nil {
+		return nil, err
+	}
+	names = append(names, tees...)
+	sort.Strings(names)
+	return names, nil
+}
+
+func fileTables() ([]string, error) {
+	files, err := os.ReadDir(config.DataDir())
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), fileSuffix) {
+			names = append(names, TableName(file.Name()))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// teeFamilies are the table prefixes tees can be cut from.
+var teeFamilies = []string{"UB", "UC", "WB", "WC"}
+
+// TeeTables returns the virtual tee tables, named after their parent with a
+// "T" suffix (e.g. UB300T), for every I-section table in the data directory.
+func TeeTables() ([]string, error) {
+	names, err := fileTables()
+	if err != nil {
+		return nil, err
+	}
+	var tees []string
+	for _, name := range names {
+		if _, ok := teeParent(name + "T"); ok {
+			tees = append(tees, name+"T")
+		}
+	}
+	return tees, nil
+}
+
+// teeParent returns the I-section table a tee table is derived from.
+func teeParent(tableName string) (string, bool) {
+	name := TableName(TableFile(tableName))
+	if !strings.HasSuffix(name, "T") {
+		return "", false
+	}
+	parent := strings.TrimSuffix(name, "T")
+	for _, f := range teeFamilies {
+		if strings.HasPrefix(parent, f) && config.FileExists(TableFile(parent)) {
+			return parent, true
+		}
+	}
+	return "", false
+}
+
+// Load reads the named table from the data directory, cutting tee tables
+// from their parent.
+func Load(tableName string) ([]models.SteelProperty, error) {
+	if parent, ok := teeParent(tableName); ok {
+		parents, err := Load(parent)
+		if err != nil {
+			return nil, err
+		}
+		return tee.Table(parents), nil
+	}
+	if !Exists(tableName) {
+		return nil, fmt.Errorf("table '%s' not found", tableName)
+	}
+	return LoadFile(config.DataFile(TableFile(tableName)))
+}
+
+// LoadFile reads a table from an explicit JSON file path.
+func LoadFile(filePath string) ([]models.SteelProperty, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filePath, err)
+	}
+	var properties []models.SteelProperty
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filePath, err)
+	}
+	return properties, nil
+}
+
+// SaveUser adds sections to the user table, creating it if needed. A row
+// with the same designation and grade as an existing one replaces it.
+func SaveUser(rows ...models.SteelProperty) error {
+	var existing []models.SteelProperty
+	if Exists(UserTable) {
+		var err error
+		if existing, err = Load(UserTable); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		replaced := false
+		for i, p := range existing {
+			if p.Grade == row.Grade && normalizeDesignation(p.Section) == normalizeDesignation(row.Section) {
+				existing[i] = row
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			existing = append(existing, row)
+		}
+	}
+
+	data, err := json.MarshalIndent(existing, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := config.DataFile(TableFile(UserTable))
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("saving %s: %w", path, err)
+	}
+	return nil
+}
+
+// Match is a row found by Find together with the table it came from.
+type Match struct {
+	Table    string
+	Property models.SteelProperty
+}
+
+// Find searches every table for a section designation such as "410UB53.7" or
+// "200x100x6.0 RHS". Spaces, case and the trailing "#" are ignored. A grade
+// may be given as a suffix, e.g. "410UB53.7 (G350)", or with grade; 0
+// matches any grade. Matches are returned in table order.
+func Find(designation string, grade int) ([]Match, error) {
+	designation, g, err := models.ParseGrade(designation)
+	if err == nil && grade == 0 {
+		grade = g
+	}
+	want := normalizeDesignation(designation)
+
+	tables, err := Tables()
+	if err != nil {
+		return nil, err
+	}
+	var matches []Match
+	for _, table := range tables {
+		properties, err := Load(table)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range properties {
+			if grade != 0 && p.Grade != grade {
+				continue
+			}
+			if normalizeDesignation(p.Section) == want {
+				matches = append(matches, Match{Table: table, Property: p})
+			}
+		}
+	}
+	return matches, nil
+}
+
+// FindOne returns the first match for a designation, or an error if none.
+// Plate girder designations such as "500x32-1200x16PG" are generated by
+// package girder rather than looked up.
+func FindOne(designation string, grade int) (Match, error) {
+	if girder.IsDesignation(designation) {
+		g, err := girder.Parse(designation, grade)
+		if err != nil {
+			return Match{}, err
+		}
+		p, err := g.Property()
+		if err != nil {
+			return Match{}, err
+		}
+		return Match{Table: "PG", Property: p}, nil
+	}
+	matches, err := Find(designation, grade)
+	if err != nil {
+		return Match{}, err
+	}
+	if len(matches) == 0 {
+		if grade != 0 {
+			return Match{}, fmt.Errorf("section '%s' not found in grade %d", designation, grade)
+		}
+		return Match{}, fmt.Errorf("section '%s' not found", designation)
+	}
+	return matches[0], nil
+}
+
+func normalizeDesignation(section string) string {
+	section, _ = models.SplitGrade(section)
+	section = strings.ReplaceAll(section, " ", "")
+	section = strings.TrimRight(section, "#")
+	return strings.ToUpper(section)
+}
@@ -0,0 +1,274 @@
+// Package cellular generates castellated and cellular beams from UB and WB
+// parents and checks them at an opening.
+//
+// A castellated beam is cut along a zig-zag line through the web and the
+// halves are rewelded tooth to tooth, giving regular hexagonal openings with
+// 60° sides: the expanded depth Dg is the parent depth plus half the opening
+// height ho, and no steel is lost. A cellular beam is cut twice along
+// offset semicircular lines, giving circular openings of diameter ho with
+// Dg - ho/2 up to the parent depth, and the strip between the cuts is
+// discarded.
+//
+// Gross properties are at the solid web post; net properties are at the
+// opening centreline, where the section is two tees. The checks follow the
+// simple Vierendeel and web-post models used for preliminary design: the
+// tees carry the global moment as axial forces and share the shear equally,
+// and the web post carries the horizontal shear from the change in tee
+// force over one opening spacing.
+package cellular
+
+import (
+	"fmt"
+	"math"
+
+	"steel_tables/internal/calc"
+	"steel_tables/internal/materials"
+	"steel_tables/internal/models"
+	"steel_tables/internal/tee"
+)
+
+// Kind is the shape of the web openings.
+type Kind string
+
+const (
+	Castellated Kind = "castellated"
+	Cellular    Kind = "cellular"
+)
+
+// ParseKind converts a command-line value to a Kind.
+func ParseKind(value string) (Kind, error) {
+	switch Kind(value) {
+	case Castellated, Cellular:
+		return Kind(value), nil
+	}
+	return "", fmt.Errorf("invalid opening kind %q: use castellated or cellular", value)
+}
+
+// Geometry describes the expanded beam (mm). Zero values take defaults:
+// a castellated beam needs only one of Depth and Opening and has regular
+// hexagons unless Spacing is given; a cellular beam needs Depth and Opening
+// and defaults to openings at 1.5 diameters.
+type Geometry struct {
+	Kind    Kind
+	Depth   float64 // Expanded depth Dg
+	Opening float64 // Opening height or diameter ho
+	Spacing float64 // Opening centres s
+}
+
+// Section holds the major axis properties at one cross-section in the
+// catalog's table units.
+type Section struct {
+	Ag float64 // mm²
+	Ix float64 // 10⁶mm⁴
+	Zx float64 // 10³mm³
+	Sx float64 // 10³mm³
+	Rx float64 // mm
+}
+
+// Beam is an expanded section and its properties.
+type Beam struct {
+	Parent models.SteelProperty
+	Geometry
+
+	PostWidth     float64 // Narrowest web post width (mm)
+	OpeningLength float64 // Length of opening the tees span in Vierendeel bending (mm)
+	Tee           models.SteelProperty
+	Gross         Section // At the web post
+	Net           Section // At the opening centreline
+	Iy            float64 // 10⁶mm⁴, gross
+	J             float64 // 10³mm⁴, gross
+	Iw            float64 // 10⁹mm⁶, gross
+	Weight        float64 // kg/m
+}
+
+// Expand cuts and rewelds a UB or WB parent.
+func Expand(parent models.SteelProperty, g Geometry) (Beam, error) {
+	family := parent.Family()
+	if family != models.FamilyUB && family != models.FamilyWB {
+		return Beam{}, fmt.Errorf("castellated and cellular beams are cut from UB and WB sections, not %s", parent.Section)
+	}
+	if g.Depth < 0 || g.Opening < 0 || g.Spacing < 0 {
+		return Beam{}, fmt.Errorf("depth, opening and spacing must be positive")
+	}
+	d, tw := parent.D, parent.Tw
+
+	b := Beam{Parent: parent}
+	switch g.Kind {
+	case Castellated:
+		switch {
+		case g.Depth == 0 && g.Opening == 0:
+			return Beam{}, fmt.Errorf("a castellated beam needs its expanded depth or opening height")
+		case g.Depth == 0:
+			g.Depth = d + g.Opening/2
+		case g.Opening == 0:
+			g.Opening = 2 * (g.Depth - d)
+		case math.Abs(g.Opening-2*(g.Depth-d)) > 0.5:
+			return Beam{}, fmt.Errorf("a castellated %s %.0f mm deep has %.0f mm openings, not %.0f mm", parent.Section, g.Depth, 2*(g.Depth-d), g.Opening)
+		}
+		if g.Spacing == 0 {
+			g.Spacing = math.Sqrt(3) * g.Opening
+		}
+		// The web post at mid-depth is as wide as the top of the opening.
+		b.PostWidth = (g.Spacing - g.Opening/math.Sqrt(3)) / 2
+		b.OpeningLength = b.PostWidth
+	case Cellular:
+		if g.Depth == 0 || g.Opening == 0 {
+			return Beam{}, fmt.Errorf("a cellular beam needs its expanded depth and opening diameter")
+		}
+		if g.Spacing == 0 {
+			g.Spacing = 1.5 * g.Opening
+		}
+		b.PostWidth = g.Spacing - g.Opening
+		// A circular opening acts like a rectangular one 0.45 diameters long.
+		b.OpeningLength = 0.45 * g.Opening
+	default:
+		return Beam{}, fmt.Errorf("invalid opening kind %q: use castellated or cellular", g.Kind)
+	}
+	b.Geometry = g
+
+	if g.Depth <= d {
+		return Beam{}, fmt.Errorf("expanded depth %.0f mm must exceed the %.0f mm parent depth", g.Depth, d)
+	}
+	// Each tee is as deep at the post as half the beam and as shallow at the
+	// opening as the tee cut from beside it, so both fit in the parent web.
+	if g.Depth-g.Opening/2 > d+0.5 {
+		return Beam{}, fmt.Errorf("%s cannot be expanded to %.0f mm with %.0f mm openings: Dg - ho/2 exceeds d", parent.Section, g.Depth, g.Opening)
+	}
+	if b.PostWidth <= 0 {
+		return Beam{}, fmt.Errorf("openings at %.0f mm centres leave no web post", g.Spacing)
+	}
+	r, _ := models.Number(parent.R1)
+	teeDepth := (g.Depth - g.Opening) / 2
+	if teeDepth < parent.Tf+r {
+		return Beam{}, fmt.Errorf("%.0f mm openings in a %.0f mm deep beam cut into the flange and root fillets", g.Opening, g.Depth)
+	}
+
+	t, err := tee.CutAt(parent, teeDepth)
+	if err != nil {
+		return Beam{}, err
+	}
+	b.Tee = t
+
+	at := t.Ag
+	ixt := t.Ix * 1e6
+	yc, _ := models.Number(t.PT)
+	arm := g.Depth/2 - yc
+	net := struct{ a, i, s float64 }{2 * at, 2 * (ixt + at*arm*arm), 2 * at * arm}
+	ho := g.Opening
+	gross := struct{ a, i, s float64 }{net.a + tw*ho, net.i + tw*math.Pow(ho, 3)/12, net.s + tw*ho*ho/4}
+	b.Net = section(net.a, net.i, net.s, g.Depth)
+	b.Gross = section(gross.a, gross.i, gross.s, g.Depth)
+
+	webPost := ho * math.Pow(tw, 3)
+	b.Iy = models.Round(2*t.Iy+webPost/12/1e6, 2)
+	b.J = models.Round(2*t.J+webPost/3/1e3, 0)
+	df := g.Depth - parent.Tf
+	b.Iw = models.Round(b.Iy*1e6*df*df/4/1e9, 0)
+
+	// The parent's mass plus the web added by the expansion, less the steel
+	// removed by the openings: nothing is lost when castellating.
+	hole := math.Pi * ho * ho / 4
+	if g.Kind == Castellated {
+		hole = ho * (b.PostWidth + ho/(2*math.Sqrt(3)))
+	}
+	added := (g.Depth - d) * tw
+	b.Weight = models.Round(parent.Weight+(added-tw*hole/g.Spacing)*models.SteelDensity, 1)
+	return b, nil
+}
+
+// Name describes the beam, e.g. "610UB101 cellular Dg 900, ho 675 @ 1012".
+func (b Beam) Name() string {
+	return fmt.Sprintf("%s %s Dg %.0f, ho %.0f @ %.0f", b.Parent.Section, b.Kind, b.Depth, b.Opening, b.Spacing)
+}
+
+// Actions are the design actions at an opening.
+type Actions struct {
+	M float64 // Design moment M* (kNm)
+	V float64 // Design shear V* (kN)
+}
+
+// Result holds the capacities and ratios of the checks at an opening.
+type Result struct {
+	Fy    float64 // Tee yield stress (MPa)
+	Fyw   float64 // Web yield stress (MPa)
+	Lever float64 // Distance between tee centroids (mm)
+
+	TeeForce float64 // Axial force in each tee, M*/lever (kN)
+	PhiNt    float64 // Tee section capacity in compression, φ kf Ag fy (kN)
+
+	PhiVo float64 // Shear capacity of the two tee stems (kN)
+
+	VierendeelMoment float64 // Moment at each end of a tee, (V*/2)(ℓ/2) (kNm)
+	PhiMt            float64 // Tee section moment capacity, φ fy Zex (kNm)
+
+	PostShear   float64 // Horizontal shear in the web post, V* s / lever (kN)
+	PhiVh       float64 // Web post shear capacity (kN)
+	PostLe      float64 // Web post strut effective length (mm)
+	PostLambdaN float64 // Web post strut modified slenderness
+	PostAlphaC  float64 // Web post strut slenderness reduction factor
+	PhiNwp      float64 // Web post buckling capacity (kN)
+
+	Checks    []calc.InteractionCheck
+	Governing calc.InteractionCheck
+}
+
+// Check performs the opening and web post checks for design actions a.
+func (b Beam) Check(a Actions) (Result, error) {
+	if a.M < 0 || a.V < 0 {
+		return Result{}, fmt.Errorf("design actions must not be negative")
+	}
+	t := b.Tee
+	yc, _ := models.Number(t.PT)
+	m := materials.Of(b.Parent)
+	res := Result{
+		Fy:    m.Fy(),
+		Fyw:   m.Web,
+		Lever: b.Depth - 2*yc,
+	}
+	if res.Fyw == 0 {
+		res.Fyw = res.Fy
+	}
+	tw := b.Parent.Tw
+
+	res.TeeForce = a.M * 1e3 / res.Lever
+	res.PhiNt = calc.PhiCompression * t.FormFactor() * t.Ag * res.Fy / 1e3
+
+	res.PhiVo = calc.PhiShear * 0.6 * res.Fyw * 2 * t.D * tw / 1e3
+
+	res.VierendeelMoment = a.V / 2 * b.OpeningLength / 2 / 1e3
+	res.PhiMt = calc.PhiBending * res.Fy * t.Zex / 1e3
+
+	res.PostShear = a.V * b.Spacing / res.Lever
+	res.PhiVh = calc.PhiShear * 0.6 * res.Fyw * b.PostWidth * tw / 1e3
+
+	// The web post buckles as a strut of its narrowest width.
+	res.PostLe = 0.5 * math.Sqrt(b.PostWidth*b.PostWidth+b.Opening*b.Opening)
+	res.PostLambdaN = res.PostLe / (tw / math.Sqrt(12)) * math.Sqrt(res.Fyw/250)
+	res.PostAlphaC = calc.SlendernessReduction(res.PostLambdaN, 0.5)
+	res.PhiNwp = calc.PhiCompression * res.PostAlphaC * b.PostWidth * tw * res.Fyw / 1e3
+
+	res.Checks = []calc.InteractionCheck{
+		{Clause: "6.2", Description: "Tee axial force from M*", Ratio: calc.Ratio(res.TeeForce, res.PhiNt)},
+		{Clause: "5.11", Description: "Shear at the opening", Ratio: calc.Ratio(a.V, res.PhiVo)},
+		{Clause: "8.3", Description: "Vierendeel bending of the tee", Ratio: calc.Ratio(res.TeeForce, res.PhiNt) + calc.Ratio(res.VierendeelMoment, res.PhiMt)},
+		{Clause: "5.11", Description: "Web post horizontal shear", Ratio: calc.Ratio(res.PostShear, res.PhiVh)},
+		{Clause: "6.3", Description: "Web post buckling", Ratio: calc.Ratio(res.PostShear, res.PhiNwp)},
+	}
+	res.Governing = res.Checks[0]
+	for _, c := range res.Checks[1:] {
+		if c.Ratio > res.Governing.Ratio {
+			res.Governing = c
+		}
+	}
+	return res, nil
+}
+
+func section(a, i, s, depth float64) Section {
+	return Section{
+		Ag: models.Round(a, 0),
+		Ix: models.Round(i/1e6, 1),
+		Zx: models.Round(i/(depth/2)/1e3, 0),
+		Sx: models.Round(s/1e3, 0),
+		Rx: models.Round(math.Sqrt(i/a), 1),
+	}
+}
@@ -0,0 +1,329 @@
+// Package classify recomputes the AS 4100 Cl. 5.2 section classification
+// and effective section moduli of catalog sections for any yield stress, so
+// that grades other than the tabulated ones can be assessed and the
+// tabulated C, N, S, Zex, Zey and kf values checked.
+//
+// Plate element slenderness λe = (b/t)√(fy/250) (fy/250 for a CHS) is
+// compared with the Table 5.2 limits for the element's support, stress
+// distribution and residual stress category. The section takes the class of
+// the element with the largest λe/λey, and Ze follows Cl. 5.2.3 to 5.2.5.
+package classify
+
+import (
+	"fmt"
+	"math"
+
+	"steel_tables/internal/models"
+)
+
+// Class is a section or element classification.
+type Class string
+
+const (
+	Compact    Class = "C"
+	NonCompact Class = "N"
+	Slender    Class = "S"
+)
+
+// Limits are the Table 5.2 plasticity and yield slenderness limits λep and
+// λey.
+type Limits struct {
+	Plastic, Yield float64
+}
+
+// Table 5.2 limits by residual stress category: stress relieved (SR), hot
+// rolled (HR), lightly welded (LW), cold-formed (CF) and heavily welded (HW).
+var (
+	// Flat, one edge supported, uniform compression.
+	OutstandUniform = map[string]Limits{"SR": {10, 16}, "HR": {9, 16}, "LW": {8, 15}, "CF": {8, 15}, "HW": {8, 14}}
+	// Flat, one edge supported, compression at the unsupported edge and zero
+	// at the supported edge.
+	OutstandGradient = map[string]Limits{"SR": {10, 25}, "HR": {9, 25}, "LW": {8, 22}, "CF": {8, 22}, "HW": {8, 22}}
+	// Flat, both edges supported, uniform compression.
+	SupportedUniform = map[string]Limits{"SR": {30, 45}, "HR": {30, 45}, "LW": {30, 40}, "CF": {30, 40}, "HW": {30, 35}}
+	// Flat, both edges supported, compression at one edge and tension at
+	// the other, for every category.
+	WebBending = Limits{82, 115}
+	// Circular hollow sections.
+	Circular = map[string]Limits{"SR": {50, 120}, "HR": {50, 120}, "CF": {50, 120}, "LW": {42, 120}, "HW": {42, 120}}
+)
+
+// CircularAxial is λey for a CHS in uniform compression (Cl. 6.2.4).
+const CircularAxial = 82.0
+
+// Element is a plate element with its slenderness and limits.
+type Element struct {
+	Name      string
+	Width     float64 // Clear width b, or outside diameter of a CHS (mm)
+	Thickness float64 // mm
+	Lambda    float64 // λe
+	Plastic   float64 // λep
+	Yield     float64 // λey
+}
+
+// Class returns the element's classification.
+func (e Element) Class() Class {
+	switch {
+	case e.Lambda <= e.Plastic:
+		return Compact
+	case e.Lambda <= e.Yield:
+		return NonCompact
+	}
+	return Slender
+}
+
+// Axis holds the classification for bending about one axis. Moduli are in
+// 10³mm³.
+type Axis struct {
+	Elements  []Element // Elements in compression
+	Governing Element   // The element with the largest λe/λey
+	Class     Class
+	Z         float64
+	S         float64
+	Zc        float64 // min(S, 1.5Z)
+	Ze        float64
+}
+
+// Result holds a section's classification at yield stress Fy.
+type Result struct {
+	Section  string
+	Fy       float64 // MPa
+	Residual string  // Residual stress category
+	X        Axis
+	Y        *Axis     // nil where the section's minor axis is not covered
+	Kf       float64   // Form factor, Cl. 6.2.4
+	Uniform  []Element // Elements in uniform compression for kf
+	Notes    []string
+}
+
+// Section classifies a catalog section for yield stress fy in MPa, using
+// the section's tabulated dimensions, Z and S. I-sections, channels (major
+// axis only), tees, RHS, SHS and CHS are covered.
+func Section(p models.SteelProperty, fy float64) (Result, error) {
+	if fy <= 0 {
+		return Result{}, fmt.Errorf("yield stress must be positive")
+	}
+	family := p.Family()
+	r := Result{Section: p.Section, Fy: fy, Residual: Residual(p)}
+	switch {
+	case family.IsISection():
+		r.iSection(p)
+	case family.IsChannel():
+		r.channel(p)
+	case family.IsTee():
+		r.tee(p)
+	case family == models.FamilyCHS:
+		r.circular(p)
+	case family.IsHollow():
+		r.rectangular(p)
+	case family.IsAngle():
+		return Result{}, fmt.Errorf("%s is an angle: angles are not covered, see the table's principal axis Zex and Zey", p.Section)
+	default:
+		return Result{}, fmt.Errorf("cannot classify %s", p.Section)
+	}
+	return r, nil
+}
+
+// Residual returns the section's residual stress category, defaulting by
+// family when the table has none.
+func Residual(p models.SteelProperty) string {
+	if _, ok := SupportedUniform[p.Residual]; ok {
+		return p.Residual
+	}
+	family := p.Family()
+	switch {
+	case family.IsWelded():
+		return "HW"
+	case family.IsHollow():
+		return "CF"
+	}
+	return "HR"
+}
+
+// Flat returns a flat element of width b and thickness t in mm, with yield
+// stress fy in MPa and limits lim.
+func Flat(name string, b, t, fy float64, lim Limits) Element {
+	return Element{
+		Name:      name,
+		Width:     b,
+		Thickness: t,
+		Lambda:    b / t * math.Sqrt(fy/250),
+		Plastic:   lim.Plastic,
+		Yield:     lim.Yield,
+	}
+}
+
+// element returns a flat element at the result's yield stress.
+func (r *Result) element(name string, b, t float64, lim Limits) Element {
+	return Flat(name, b, t, r.Fy, lim)
+}
+
+// Bending classifies bending with the given elements in compression, using
+// Ze = Z(λsy/λs) for a slender section. z, s and Ze share their units.
+func Bending(z, s float64, elements ...Element) Axis {
+	a := Axis{Elements: elements, Governing: elements[0], Z: z, S: s, Zc: math.Min(s, 1.5*z)}
+	for _, e := range elements[1:] {
+		if e.Lambda/e.Yield > a.Governing.Lambda/a.Governing.Yield {
+			a.Governing = e
+		}
+	}
+	g := a.Governing
+	a.Class = g.Class()
+	switch a.Class {
+	case Compact:
+		a.Ze = a.Zc
+	case NonCompact:
+		a.Ze = z + (g.Yield-g.Lambda)/(g.Yield-g.Plastic)*(a.Zc-z)
+	default:
+		a.Ze = z * g.Yield / g.Lambda
+	}
+	return a
+}
+
+// Ineffective returns the area in mm² of a flat element of width b and
+// thickness t in uniform compression that lies outside its effective width
+// be = b λey/λe (Cl. 6.2.4). kf = 1 - ΣIneffective/Ag.
+func Ineffective(b, t, lambda, yield float64) float64 {
+	return b * (1 - math.Min(1, yield/lambda)) * t
+}
+
+// formFactor sets kf from the effective widths of the elements in uniform
+// compression, each counted n times.
+func (r *Result) formFactor(ag float64, elements []Element, counts []float64) {
+	r.Uniform = elements
+	ineffective := 0.0
+	for i, e := range elements {
+		ineffective += counts[i] * Ineffective(e.Width, e.Thickness, e.Lambda, e.Yield)
+	}
+	r.Kf = 1 - ineffective/ag
+}
+
+func (r *Result) iSection(p models.SteelProperty) {
+	d1 := p.D1
+	if d1 <= 0 {
+		d1 = p.D - p.Tf - math.Max(p.Tf, p.Tf2)
+	}
+	outstand := (p.Bf - p.Tw) / 2
+	web := r.element("web", d1, p.Tw, WebBending)
+	r.X = Bending(p.Zx, p.Sx, r.element("flange outstand", outstand, p.Tf, OutstandUniform[r.Residual]), web)
+	y := Bending(p.Zy, p.Sy, r.element("flange outstand", outstand, p.Tf, OutstandGradient[r.Residual]))
+
+	uniform := []Element{
+		r.element("flange outstand", outstand, p.Tf, OutstandUniform[r.Residual]),
+		r.element("web", d1, p.Tw, SupportedUniform[r.Residual]),
+	}
+	counts := []float64{4, 1}
+	if p.Bf2 > 0 && (p.Bf2 != p.Bf || p.Tf2 != p.Tf) {
+		// Unequal flanges: the top flange is in compression for major axis
+		// bending, and the minor axis is governed by the more slender one.
+		bottom := (p.Bf2 - p.Tw) / 2
+		uniform = append(uniform, r.element("bottom flange outstand", bottom, p.Tf2, OutstandUniform[r.Residual]))
+		counts = []float64{2, 1, 2}
+		y = Bending(p.Zy, p.Sy,
+			r.element("flange outstand", outstand, p.Tf, OutstandGradient[r.Residual]),
+			r.element("bottom flange outstand", bottom, p.Tf2, OutstandGradient[r.Residual]))
+		r.Notes = append(r.Notes, "Major axis bending takes the top flange in compression.")
+	}
+	r.Y = &y
+	r.formFactor(p.Ag, uniform, counts)
+}
+
+func (r *Result) channel(p models.SteelProperty) {
+	d1 := p.D1
+	if d1 <= 0 {
+		d1 = p.D - 2*p.Tf
+	}
+	flange := r.element("flange outstand", p.Bf-p.Tw, p.Tf, OutstandUniform[r.Residual])
+	r.X = Bending(p.Zx, p.Sx, flange, r.element("web", d1, p.Tw, WebBending))
+	r.Notes = append(r.Notes, "Minor axis bending of a channel is not covered.")
+	r.formFactor(p.Ag, []Element{flange, r.element("web", d1, p.Tw, SupportedUniform[r.Residual])}, []float64{2, 1})
+}
+
+// tee classifies a tee for bending about x with either the flange or the
+// stem tip in compression, whichever governs.
+func (r *Result) tee(p models.SteelProperty) {
+	stem := p.D - p.Tf
+	outstand := (p.Bf - p.Tw) / 2
+	r.X = Bending(p.Zx, p.Sx,
+		r.element("flange outstand", outstand, p.Tf, OutstandUniform[r.Residual]),
+		r.element("stem", stem, p.Tw, OutstandGradient[r.Residual]))
+	y := Bending(p.Zy, p.Sy, r.element("flange outstand", outstand, p.Tf, OutstandGradient[r.Residual]))
+	r.Y = &y
+	r.formFactor(p.Ag, []Element{
+		r.element("flange outstand", outstand, p.Tf, OutstandUniform[r.Residual]),
+		r.element("stem", stem, p.Tw, OutstandUniform[r.Residual]),
+	}, []float64{2, 1})
+}
+
+func (r *Result) rectangular(p models.SteelProperty) {
+	t := p.Tf
+	bw, dw := p.Bf-2*t, p.D-2*t
+	lim := SupportedUniform[r.Residual]
+	flange := r.element("flange", bw, t, lim)
+	side := r.element("web", dw, t, lim)
+	r.X = RectangularBending(p.Ag, p.Ix, p.Zx, p.Sx, p.D, flange, r.element("web", dw, t, WebBending))
+	y := RectangularBending(p.Ag, p.Iy, p.Zy, p.Sy, p.Bf, side, r.element("flange", bw, t, WebBending))
+	r.Y = &y
+	r.formFactor(p.Ag, []Element{flange, side}, []float64{2, 2})
+}
+
+// RectangularBending classifies an RHS with one wall in uniform compression
+// and the two side walls in bending. A slender compression wall uses its
+// effective width (Cl. 5.2.5); Ze is the elastic modulus of the remaining
+// section about its shifted axis. ag is in mm², depth in mm, i in 10⁶mm⁴,
+// and z, s and Ze in 10³mm³.
+func RectangularBending(ag, i, z, s, depth float64, flange, web Element) Axis {
+	a := Bending(z, s, flange, web)
+	if a.Class != Slender || a.Governing.Name != flange.Name {
+		return a
+	}
+	t := flange.Thickness
+	removed := flange.Width * (1 - flange.Yield/flange.Lambda) * t
+	arm := depth/2 - t/2
+	shift := removed * arm / (ag - removed)
+	ie := i*1e6 - removed*t*t/12 - removed*arm*arm - (ag-removed)*shift*shift
+	a.Ze = ie / (depth/2 + shift) / 1e3
+	return a
+}
+
+func (r *Result) circular(p models.SteelProperty) {
+	e := Wall(p.D, p.Tf, r.Fy, Circular[r.Residual])
+	r.X = CircularBending(p.Zx, p.Sx, e)
+	y := r.X
+	y.Z, y.S, y.Zc = p.Zy, p.Sy, math.Min(p.Sy, 1.5*p.Zy)
+	y.Ze = r.X.Ze * p.Zy / p.Zx
+	r.Y = &y
+	axial := e
+	axial.Yield = CircularAxial
+	r.Uniform = []Element{axial}
+	r.Kf = CircularFormFactor(e)
+}
+
+// Wall returns the wall of a CHS of outside diameter d and thickness t in
+// mm, with λe = (d/t)(fy/250) for yield stress fy in MPa and limits lim.
+func Wall(d, t, fy float64, lim Limits) Element {
+	return Element{
+		Name:      "wall",
+		Width:     d,
+		Thickness: t,
+		Lambda:    d / t * fy / 250,
+		Plastic:   lim.Plastic,
+		Yield:     lim.Yield,
+	}
+}
+
+// CircularBending classifies a CHS for bending. A slender CHS takes the
+// lower Ze of Cl. 5.2.5 (b).
+func CircularBending(z, s float64, wall Element) Axis {
+	a := Bending(z, s, wall)
+	if a.Class == Slender {
+		a.Ze = math.Min(z*math.Sqrt(wall.Yield/wall.Lambda), z*math.Pow(2*wall.Yield/wall.Lambda, 2))
+	}
+	return a
+}
+
+// CircularFormFactor returns kf for a CHS wall in uniform compression
+// (Cl. 6.2.4).
+func CircularFormFactor(wall Element) float64 {
+	return math.Min(1, math.Min(math.Sqrt(CircularAxial/wall.Lambda), math.Pow(3*CircularAxial/wall.Lambda, 2)))
+}
@@ -3,8 +3,11 @@ package columns
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 
+	"steel_tables/internal/calc"
 	"steel_tables/internal/models"
 )
 
@@ -19,9 +22,15 @@ var unitMap = map[string]string{
 	"Weight": "kg/m", "d": "mm", "bf": "mm", "tf": "mm", "tw": "mm", "r1": "mm", "d1": "mm",
 	"tw__1": "mm", "tf__1": "mm", "Ag": "mm²", "Ix": "10⁶mm⁴", "Zx": "10³mm³", "Sx": "10³mm³",
 	"rx": "mm", "Iy": "10⁶mm⁴", "Zy": "mm³", "Sy": "mm³", "ry": "mm", "J": "10³mm⁴", "Iw": "10⁹mm⁶",
-	"flange": "mm", "web": "mm", "Zex": "mm³", "Zey": "mm³", "Zy5": "mm³", "Fu": "MPa", "r2": "mm",
-	"ZeyD": "mm³", "In": "10³mm⁴", "Ip": "10³mm⁴", "ZexC": "mm³", "x5": "mm", "y5": "mm", "nL": "mm",
-	"pB": "mm", "pT": "mm",
+	"flange": "mm", "web": "mm", "Zex": "mm³", "Zey": "mm³", "ZeyL": "mm³", "ZeyR": "mm³", "Zy5": "mm³", "Fu": "MPa", "r2": "mm",
+	"ZeyD": "mm³", "In": "10⁶mm⁴", "Ip": "10⁶mm⁴", "ZexC": "mm³", "x5": "mm", "y5": "mm", "nL": "mm",
+	"pB": "mm", "pT": "mm", "xL": "mm", "Xo": "mm", "bf2": "mm", "tf2": "mm", "C": "10³mm³", "ZxT": "10³mm³", "ZxB": "10³mm³",
+	"φMsx": "kNm", "φMsy": "kNm", "φNs": "kN", "φNt": "kN", "φVv": "kN",
+}
+
+// Unit returns the display unit for a column, or "" if it has none.
+func Unit(columnName string) string {
+	return unitMap[columnName]
 }
 
 // GetHeaderWithUnit returns the column name with its unit.
@@ -67,6 +76,18 @@ func GetAll() []ColumnInfo {
 		{"d", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.D) }},
 		{"bf", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.Bf) }},
 		{"tf", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.Tf) }},
+		{"bf2", func(p models.SteelProperty) string {
+			if p.Bf2 == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", p.Bf2)
+		}},
+		{"tf2", func(p models.SteelProperty) string {
+			if p.Tf2 == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", p.Tf2)
+		}},
 		{"tw", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.Tw) }},
 		{"r1", func(p models.SteelProperty) string { return FormatInterface(p.R1) }},
 		{"d1", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.D1) }},
@@ -75,6 +96,18 @@ func GetAll() []ColumnInfo {
 		{"Ag", func(p models.SteelProperty) string { return fmt.Sprintf("%.0f", p.Ag) }},
 		{"Ix", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.Ix) }},
 		{"Zx", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.Zx) }},
+		{"ZxT", func(p models.SteelProperty) string {
+			if p.ZxT == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", p.ZxT)
+		}},
+		{"ZxB", func(p models.SteelProperty) string {
+			if p.ZxB == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", p.ZxB)
+		}},
 		{"Sx", func(p models.SteelProperty) string { return fmt.Sprintf("%.0f", p.Sx) }},
 		{"rx", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.Rx) }},
 		{"Iy", func(p models.SteelProperty) string { return fmt.Sprintf("%.2f", p.Iy) }},
@@ -83,6 +116,12 @@ func GetAll() []ColumnInfo {
 		{"ry", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.Ry) }},
 		{"J", func(p models.SteelProperty) string { return fmt.Sprintf("%.0f", p.J) }},
 		{"Iw", func(p models.SteelProperty) string { return FormatInterface(p.Iw) }},
+		{"C", func(p models.SteelProperty) string {
+			if p.C == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", p.C)
+		}},
 		{"flange", func(p models.SteelProperty) string { return FormatInterface(p.Flange) }},
 		{"web", func(p models.SteelProperty) string { return FormatInterface(p.Web) }},
 		{"kf", func(p models.SteelProperty) string { return FormatInterface(p.Kf) }},
@@ -90,6 +129,18 @@ func GetAll() []ColumnInfo {
 		{"Zex", func(p models.SteelProperty) string { return fmt.Sprintf("%.0f", p.Zex) }},
 		{"C,N,S__1", func(p models.SteelProperty) string { return FormatInterface(p.CNS2) }},
 		{"Zey", func(p models.SteelProperty) string { return fmt.Sprintf("%.1f", p.Zey) }},
+		{"ZeyL", func(p models.SteelProperty) string {
+			if p.ZeyL == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", p.ZeyL)
+		}},
+		{"ZeyR", func(p models.SteelProperty) string {
+			if p.ZeyR == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", p.ZeyR)
+		}},
 		{"2tf", func(p models.SteelProperty) string { return FormatInterface(p.TwoTf) }},
 		{"Zy5", func(p models.SteelProperty) string {
 			if p.Zy5 == 0 {
@@ -150,6 +201,20 @@ func GetAll() []ColumnInfo {
 			return fmt.Sprintf("%.1f", p.PB)
 		}},
 		{"pT", func(p models.SteelProperty) string { return FormatInterface(p.PT) }},
+		{"xL", func(p models.SteelProperty) string {
+			if p.XL == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", p.XL)
+		}},
+		{"Xo", func(p models.SteelProperty) string {
+			if p.Xo == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f", p.Xo)
+		}},
+		{"Doubler", func(p models.SteelProperty) string { return FormatInterface(p.Doubler) }},
+		{"Stiffener", func(p models.SteelProperty) string { return FormatInterface(p.Stiffener) }},
 		{"Residual", func(p models.SteelProperty) string {
 			if p.Residual == "" {
 				return "-"
@@ -160,6 +225,27 @@ func GetAll() []ColumnInfo {
 	}
 }
 
+// GetCapacities returns read-only AS 4100 section capacity columns computed
+// from each row.
+func GetCapacities() []ColumnInfo {
+	capacity := func(value func(calc.SectionCapacity) float64) func(models.SteelProperty) string {
+		return func(p models.SteelProperty) string {
+			v := value(calc.Section(p))
+			if v <= 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.0f", v)
+		}
+	}
+	return []ColumnInfo{
+		{"φMsx", capacity(func(c calc.SectionCapacity) float64 { return c.PhiMsx })},
+		{"φMsy", capacity(func(c calc.SectionCapacity) float64 { return c.PhiMsy })},
+		{"φNs", capacity(func(c calc.SectionCapacity) float64 { return c.PhiNs })},
+		{"φNt", capacity(func(c calc.SectionCapacity) float64 { return c.PhiNt })},
+		{"φVv", capacity(func(c calc.SectionCapacity) float64 { return c.PhiVv })},
+	}
+}
+
 // FilterAvailable returns only columns that have meaningful data.
 func FilterAvailable(allColumns []ColumnInfo, properties []models.SteelProperty) []ColumnInfo {
 	var availableColumns []ColumnInfo
@@ -185,3 +271,50 @@ func FilterAvailable(allColumns []ColumnInfo, properties []models.SteelProperty)
 	}
 	return availableColumns
 }
+
+// Select returns the columns named in names, in the order given.
+func Select(allColumns []ColumnInfo, names []string) ([]ColumnInfo, error) {
+	var selected []ColumnInfo
+	for _, name := range names {
+		col, ok := Find(allColumns, name)
+		if !ok {
+			return nil, fmt.Errorf("unknown column '%s'", name)
+		}
+		selected = append(selected, col)
+	}
+	return selected, nil
+}
+
+// Find looks up a column by name, ignoring case when there is no exact match.
+func Find(allColumns []ColumnInfo, name string) (ColumnInfo, bool) {
+	for _, col := range allColumns {
+		if col.Name == name {
+			return col, true
+		}
+	}
+	for _, col := range allColumns {
+		if strings.EqualFold(col.Name, name) {
+			return col, true
+		}
+	}
+	return ColumnInfo{}, false
+}
+
+// NumericValue parses a formatted column value as a number.
+// Returns false for dashes, blanks, text and non-finite values.
+func NumericValue(value string) (float64, bool) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// CleanSectionName strips the grade suffix, e.g. "410UB53.7 (G300)" -> "410UB53.7".
+func CleanSectionName(section string) string {
+	name, suffix := models.SplitGrade(section)
+	if end := strings.Index(suffix, ")"); end != -1 {
+		return name + suffix[end+1:]
+	}
+	return section
+}
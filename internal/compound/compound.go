@@ -0,0 +1,342 @@
+// Package compound builds properties of sections made from two catalog
+// angles or channels.
+//
+// A compound designation names the component and its arrangement, with an
+// optional gap in mm between the backs (or toes) of the components:
+//
+//	2/75x75x6EA/LLBB/10        equal angles back to back with a 10 mm gap
+//	2/150(v)x100x10UA/SLBB/12  unequal angles, short legs back to back
+//	2/380x100PFC/BTB/20        channels back to back
+//	2/200x75PFC/BOX            channels toe to toe, welded into a box
+//
+// The x axis is horizontal with the backs (or toes) vertical on the y axis,
+// which is an axis of symmetry.
+package compound
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/catalog"
+	"steel_tables/internal/models"
+)
+
+// Arrangement is how the two components are placed.
+type Arrangement string
+
+const (
+	LongLegsBackToBack  Arrangement = "LLBB"
+	ShortLegsBackToBack Arrangement = "SLBB"
+	BackToBack          Arrangement = "BTB"
+	Boxed               Arrangement = "BOX"
+)
+
+// Arrangements lists the arrangements in prompt order.
+var Arrangements = []Arrangement{LongLegsBackToBack, ShortLegsBackToBack, BackToBack, Boxed}
+
+// ParseArrangement converts an arrangement name to an Arrangement.
+func ParseArrangement(value string) (Arrangement, error) {
+	a := Arrangement(strings.ToUpper(strings.TrimSpace(value)))
+	for _, known := range Arrangements {
+		if a == known {
+			return a, nil
+		}
+	}
+	return "", fmt.Errorf("invalid arrangement '%s' (want LLBB, SLBB, BTB or BOX)", value)
+}
+
+// Spec describes a compound section.
+type Spec struct {
+	Arrangement Arrangement
+	Gap         float64 // Gap between the backs or toes (mm)
+}
+
+// IsCompound reports whether a designation names a compound section.
+func IsCompound(designation string) bool {
+	return strings.HasPrefix(strings.TrimSpace(designation), "2/")
+}
+
+// Parse splits a compound designation into the component designation and
+// the spec. The arrangement defaults to LLBB for angles and BTB for
+// channels.
+func Parse(designation string) (string, Spec, error) {
+	parts := strings.Split(strings.TrimSpace(designation), "/")
+	if len(parts) < 2 || len(parts) > 4 || parts[0] != "2" || parts[1] == "" {
+		return "", Spec{}, fmt.Errorf("invalid compound section '%s' (want 2/SECTION[/ARRANGEMENT[/GAP]])", designation)
+	}
+	var spec Spec
+	if len(parts) >= 3 && parts[2] != "" {
+		a, err := ParseArrangement(parts[2])
+		if err != nil {
+			return "", Spec{}, err
+		}
+		spec.Arrangement = a
+	}
+	if len(parts) == 4 {
+		gap, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(parts[3]), "mm"), 64)
+		if err != nil || gap < 0 {
+			return "", Spec{}, fmt.Errorf("invalid gap '%s' in '%s'", parts[3], designation)
+		}
+		spec.Gap = gap
+	}
+	return parts[1], spec, nil
+}
+
+// FindOne looks up a section by designation. Compound designations are
+// built from their catalog component; anything else is passed to
+// catalog.FindOne.
+func FindOne(designation string, grade int) (catalog.Match, error) {
+	if !IsCompound(designation) {
+		return catalog.FindOne(designation, grade)
+	}
+	component, spec, err := Parse(designation)
+	if err != nil {
+		return catalog.Match{}, err
+	}
+	match, err := catalog.FindOne(component, grade)
+	if err != nil {
+		return catalog.Match{}, err
+	}
+	p, err := Build(match.Property, spec)
+	if err != nil {
+		return catalog.Match{}, err
+	}
+	return catalog.Match{Table: "2/" + match.Table, Property: p}, nil
+}
+
+// Name returns the compound designation for a component row.
+func Name(component models.SteelProperty, spec Spec) string {
+	name, suffix := models.SplitGrade(component.Section)
+	name = strings.ReplaceAll(strings.TrimSuffix(name, "#"), " ", "")
+	gap := ""
+	if spec.Gap > 0 {
+		gap = "/" + strconv.FormatFloat(spec.Gap, 'f', -1, 64)
+	}
+	return fmt.Sprintf("2/%s/%s%s%s", name, spec.Arrangement, gap, suffix)
+}
+
+// Build returns the properties of two components in the given arrangement.
+// Elastic properties come from the component's table values; plastic moduli
+// for angles about x use the legs as rectangles without root radii. J is
+// the sum for open arrangements and Bredt's formula for a box closed with no
+// gap.
+func Build(component models.SteelProperty, spec Spec) (models.SteelProperty, error) {
+	family := component.Family()
+	if spec.Arrangement == "" {
+		spec.Arrangement = BackToBack
+		if family.IsAngle() {
+			spec.Arrangement = LongLegsBackToBack
+		}
+	}
+	switch {
+	case family.IsAngle():
+		if spec.Arrangement != LongLegsBackToBack && spec.Arrangement != ShortLegsBackToBack {
+			return models.SteelProperty{}, fmt.Errorf("angles can be arranged LLBB or SLBB, not %s", spec.Arrangement)
+		}
+		return buildAngles(component, spec), nil
+	case family.IsChannel():
+		if spec.Arrangement != BackToBack && spec.Arrangement != Boxed {
+			return models.SteelProperty{}, fmt.Errorf("channels can be arranged BTB or BOX, not %s", spec.Arrangement)
+		}
+		if component.XL == 0 {
+			return models.SteelProperty{}, fmt.Errorf("%s has no centroid position xL", component.Section)
+		}
+		return buildChannels(component, spec), nil
+	}
+	return models.SteelProperty{}, fmt.Errorf("compound sections are built from angles or channels, not %s", component.Section)
+}
+
+// base copies the material data shared by both components.
+func base(c models.SteelProperty, spec Spec) models.SteelProperty {
+	return models.SteelProperty{
+		Section:  Name(c, spec),
+		Grade:    c.Grade,
+		Weight:   2 * c.Weight,
+		Ag:       2 * c.Ag,
+		Flange:   c.Flange,
+		Web:      c.Web,
+		Kf:       c.Kf,
+		AlphaB:   c.AlphaB,
+		Fu:       c.Fu,
+		Residual: c.Residual,
+		Iw:       "-",
+	}
+}
+
+func buildAngles(c models.SteelProperty, spec Spec) models.SteelProperty {
+	t := c.Tf
+	// Vertical (back) leg length v and horizontal leg length h; iv is the
+	// angle's second moment about its horizontal geometric axis, ih about
+	// its vertical one; xc and yc locate its centroid from the back of the
+	// vertical leg and the underside of the horizontal leg.
+	v, h := c.D, c.Bf
+	iv, ih := c.In, c.Ip
+	xc, yc := c.NL, c.PB
+	if spec.Arrangement == ShortLegsBackToBack {
+		v, h = h, v
+		iv, ih = ih, iv
+		xc, yc = yc, xc
+	}
+	g := spec.Gap / 2
+
+	p := base(c, spec)
+	p.D = v
+	p.Bf = 2*h + spec.Gap
+	p.Tf = t
+	p.Tw = 2 * t
+	p.D1 = v - t
+	p.PB, p.PT = yc, v-yc
+	p.Ix = 2 * iv
+	p.Iy = 2 * (ih + c.Ag*math.Pow(xc+g, 2)/1e6)
+	p.Zx = p.Ix * 1e3 / math.Max(yc, v-yc)
+	p.Zy = p.Iy * 1e3 / (h + g)
+	p.Rx = math.Sqrt(p.Ix * 1e6 / p.Ag)
+	p.Ry = math.Sqrt(p.Iy * 1e6 / p.Ag)
+	p.J = 2 * c.J
+
+	legs := []rect{{0, t, 0, v}, {t, h, 0, t}}
+	p.Sx = 2 * plasticModulusX(legs) / 1e3
+	p.Sy = 2 * c.Ag * (xc + g) / 1e3
+
+	class, ratio := worseClass(c.CNS, c.CNS2, ratioOf(c.Zex, c.Zx), ratioOf(c.ZeyD, c.Zy5))
+	p.Zex = effectiveModulus(p.Zx, p.Sx, class, ratio)
+	p.Zey = effectiveModulus(p.Zy, p.Sy, class, ratio)
+	p.CNS, p.CNS2 = class, class
+	return p
+}
+
+func buildChannels(c models.SteelProperty, spec Spec) models.SteelProperty {
+	g := spec.Gap / 2
+	// Distance from the y axis to each channel's centroid.
+	arm := c.XL + g
+	if spec.Arrangement == Boxed {
+		arm = c.Bf - c.XL + g
+	}
+
+	p := base(c, spec)
+	p.D = c.D
+	p.Bf = 2*c.Bf + spec.Gap
+	p.Tf = c.Tf
+	p.Tw = 2 * c.Tw
+	p.D1 = c.D1
+	p.PB, p.PT = c.D/2, c.D/2
+	p.Ix = 2 * c.Ix
+	p.Iy = 2 * (c.Iy + c.Ag*arm*arm/1e6)
+	p.Zx = 2 * c.Zx
+	p.Zy = p.Iy * 1e3 / (c.Bf + g)
+	p.Rx = c.Rx
+	p.Ry = math.Sqrt(p.Iy * 1e6 / p.Ag)
+	p.Sx = 2 * c.Sx
+	p.Sy = 2 * c.Ag * arm / 1e3
+	p.Zex = 2 * c.Zex
+	p.Zey = effectiveModulus(p.Zy, p.Sy, classOf(c.CNS2), 1)
+	p.CNS, p.CNS2 = c.CNS, c.CNS2
+
+	p.J = 2 * c.J
+	if spec.Arrangement == Boxed && spec.Gap == 0 {
+		// Closed box: Bredt's formula on the centreline.
+		bm := 2*c.Bf - c.Tw
+		hm := c.D - c.Tf
+		am := bm * hm
+		p.J = 4 * am * am / (2*bm/c.Tf + 2*hm/c.Tw) / 1e3
+	}
+	if spec.Arrangement == BackToBack {
+		// Doubly symmetric like an I-section: Iw = Iy·h²/4.
+		h := c.D - c.Tf
+		p.Iw = p.Iy * 1e6 * h * h / 4 / 1e9
+	}
+	return p
+}
+
+// rect is a rectangle from x0 to x1 and y0 to y1 (mm).
+type rect struct {
+	x0, x1, y0, y1 float64
+}
+
+// plasticModulusX returns the plastic modulus (mm³) of the rectangles about
+// the horizontal axis that halves their area.
+func plasticModulusX(rs []rect) float64 {
+	total := 0.0
+	top := 0.0
+	for _, r := range rs {
+		total += (r.x1 - r.x0) * (r.y1 - r.y0)
+		top = math.Max(top, r.y1)
+	}
+	below := func(y float64) float64 {
+		a := 0.0
+		for _, r := range rs {
+			a += (r.x1 - r.x0) * math.Max(0, math.Min(y, r.y1)-r.y0)
+		}
+		return a
+	}
+	lo, hi := 0.0, top
+	for i := 0; i < 60; i++ {
+		mid := (lo + hi) / 2
+		if below(mid) < total/2 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	axis := (lo + hi) / 2
+	s := 0.0
+	for _, r := range rs {
+		w := r.x1 - r.x0
+		if r.y0 < axis {
+			y1 := math.Min(r.y1, axis)
+			s += w * (y1 - r.y0) * (axis - (r.y0+y1)/2)
+		}
+		if r.y1 > axis {
+			y0 := math.Max(r.y0, axis)
+			s += w * (r.y1 - y0) * ((y0+r.y1)/2 - axis)
+		}
+	}
+	return s
+}
+
+// classOf returns the compactness class "C", "N" or "S" of a table value.
+func classOf(v interface{}) string {
+	if s, ok := v.(string); ok {
+		switch strings.ToUpper(strings.TrimSpace(s)) {
+		case "C":
+			return "C"
+		case "S":
+			return "S"
+		}
+	}
+	return "N"
+}
+
+// worseClass returns the less compact of two classes and the smaller
+// effective to elastic modulus ratio.
+func worseClass(a, b interface{}, ra, rb float64) (string, float64) {
+	rank := map[string]int{"C": 0, "N": 1, "S": 2}
+	ca, cb := classOf(a), classOf(b)
+	class := ca
+	if rank[cb] > rank[ca] {
+		class = cb
+	}
+	return class, math.Min(ra, rb)
+}
+
+func ratioOf(ze, z float64) float64 {
+	if ze <= 0 || z <= 0 {
+		return 1
+	}
+	return ze / z
+}
+
+// effectiveModulus returns Ze for the compound from its elastic modulus z
+// and plastic modulus s: min(S, 1.5Z) when compact, Z when non-compact
+// (conservative) and Z reduced by the component's Ze/Z when slender.
+func effectiveModulus(z, s float64, class string, ratio float64) float64 {
+	switch class {
+	case "C":
+		return math.Min(s, 1.5*z)
+	case "S":
+		return z * math.Min(1, ratio)
+	}
+	return z
+}
@@ -55,3 +55,9 @@ func DataDir() string {
 func DataFile(filename string) string {
 	return filepath.Join(dataDir, filename)
 }
+
+// FileExists reports whether a file exists in the data directory.
+func FileExists(filename string) bool {
+	info, err := os.Stat(DataFile(filename))
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,28 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// writeDelimited writes CSV or TSV. Multiple tables are separated by a blank line
+// and each repeats its header row.
+func writeDelimited(w io.Writer, comma rune, tables []Table) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	for i, table := range tables {
+		if i > 0 {
+			if err := cw.Write(nil); err != nil {
+				return err
+			}
+		}
+		if err := cw.Write(table.Headers()); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(table.Rows()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,121 @@
+// Package export writes steel property tables to file formats.
+package export
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"steel_tables/internal/columns"
+	"steel_tables/internal/models"
+)
+
+// Format identifies an export file format.
+type Format string
+
+// Supported export formats.
+const (
+	CSV      Format = "csv"
+	TSV      Format = "tsv"
+	JSON     Format = "json"
+	XLSX     Format = "xlsx"
+	Markdown Format = "md"
+	HTML     Format = "html"
+	LaTeX    Format = "tex"
+)
+
+// formats lists every supported format in the order shown in help text.
+var formats = []Format{CSV, TSV, JSON, XLSX, Markdown, HTML, LaTeX}
+
+// aliases maps alternative names and file extensions to formats.
+var aliases = map[string]Format{
+	"markdown": Markdown,
+	"htm":      HTML,
+	"latex":    LaTeX,
+}
+
+// Table is a named set of rows and the columns to write for them.
+type Table struct {
+	Name       string
+	Properties []models.SteelProperty
+	Columns    []columns.ColumnInfo
+}
+
+// Headers returns the header row, starting with Section, with units attached.
+func (t Table) Headers() []string {
+	headers := []string{"Section"}
+	for _, col := range t.Columns {
+		headers = append(headers, columns.GetHeaderWithUnit(col.Name))
+	}
+	return headers
+}
+
+// Rows returns the formatted cell values for every row.
+func (t Table) Rows() [][]string {
+	rows := make([][]string, len(t.Properties))
+	for i, p := range t.Properties {
+		row := []string{columns.CleanSectionName(p.Section)}
+		for _, col := range t.Columns {
+			row = append(row, col.Formatter(p))
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// IsBinary reports whether the format should not be written to a terminal.
+func (f Format) IsBinary() bool {
+	return f == XLSX
+}
+
+// Formats returns the names of all supported formats.
+func Formats() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = string(f)
+	}
+	return names
+}
+
+// ParseFormat parses a --format flag value.
+func ParseFormat(value string) (Format, error) {
+	value = strings.ToLower(strings.TrimPrefix(value, "."))
+	for _, f := range formats {
+		if string(f) == value {
+			return f, nil
+		}
+	}
+	if f, ok := aliases[value]; ok {
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown format '%s' (want %s)", value, strings.Join(Formats(), ", "))
+}
+
+// FormatFromPath infers the format from a file extension.
+func FormatFromPath(path string) (Format, bool) {
+	f, err := ParseFormat(filepath.Ext(path))
+	return f, err == nil
+}
+
+// Write writes the tables to w in the given format. Formats that hold a
+// single table write the tables one after another.
+func Write(w io.Writer, format Format, tables []Table) error {
+	switch format {
+	case CSV:
+		return writeDelimited(w, ',', tables)
+	case TSV:
+		return writeDelimited(w, '\t', tables)
+	case JSON:
+		return writeJSON(w, tables)
+	case XLSX:
+		return writeXLSX(w, tables)
+	case Markdown:
+		return writeMarkdown(w, tables)
+	case HTML:
+		return writeHTML(w, tables)
+	case LaTeX:
+		return writeLaTeX(w, tables)
+	}
+	return fmt.Errorf("unsupported format '%s'", format)
+}
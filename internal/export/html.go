@@ -0,0 +1,72 @@
+package export
+
+import (
+	"bufio"
+	"html"
+	"io"
+
+	"steel_tables/internal/columns"
+)
+
+// htmlStyle mirrors the Tokyo Midnight colours used by the terminal viewer.
+const htmlStyle = `body { background: #1a1b26; color: #c0caf5; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; }
+h1, h2 { color: #6fecce; font-weight: 600; }
+.table-wrap { max-height: 85vh; overflow: auto; border: 1px solid #3c3f53; margin-bottom: 2em; }
+table { border-collapse: separate; border-spacing: 0; font-variant-numeric: tabular-nums; white-space: nowrap; }
+th, td { padding: 4px 12px; text-align: right; }
+th:first-child, td:first-child { text-align: left; position: sticky; left: 0; }
+thead th { position: sticky; top: 0; z-index: 2; background: #24283b; color: #6fecce; border-bottom: 2px solid #7aa2f7; }
+thead th:first-child { z-index: 3; }
+thead .unit { display: block; color: #7383a8; font-weight: normal; font-size: 0.85em; }
+tbody tr:nth-child(odd) td { background: #1a1b26; }
+tbody tr:nth-child(even) td { background: #24283b; }
+tbody td:first-child { color: #ffffff; }
+tbody td.empty { color: #7383a8; }
+tbody tr:hover td { background: #3c3f53; }
+`
+
+// writeHTML writes a standalone HTML page with sticky header rows and
+// first column, and alternating row colours matching the viewer theme.
+func writeHTML(w io.Writer, tables []Table) error {
+	bw := bufio.NewWriter(w)
+	title := "Steel Properties"
+	if len(tables) == 1 {
+		title = "Steel Properties: " + tables[0].Name
+	}
+
+	bw.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	bw.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+	bw.WriteString("<style>\n" + htmlStyle + "</style>\n</head>\n<body>\n")
+	bw.WriteString("<h1>" + html.EscapeString(title) + "</h1>\n")
+
+	for _, table := range tables {
+		if len(tables) > 1 {
+			bw.WriteString("<h2>" + html.EscapeString(table.Name) + "</h2>\n")
+		}
+		bw.WriteString("<div class=\"table-wrap\">\n<table>\n<thead>\n<tr><th>Section</th>")
+		for _, col := range table.Columns {
+			bw.WriteString("<th>" + html.EscapeString(col.Name))
+			if unit := columns.Unit(col.Name); unit != "" {
+				bw.WriteString("<span class=\"unit\">" + html.EscapeString(unit) + "</span>")
+			}
+			bw.WriteString("</th>")
+		}
+		bw.WriteString("</tr>\n</thead>\n<tbody>\n")
+
+		for _, row := range table.Rows() {
+			bw.WriteString("<tr>")
+			for _, value := range row {
+				if value == "-" || value == "" {
+					bw.WriteString("<td class=\"empty\">-</td>")
+				} else {
+					bw.WriteString("<td>" + html.EscapeString(value) + "</td>")
+				}
+			}
+			bw.WriteString("</tr>\n")
+		}
+		bw.WriteString("</tbody>\n</table>\n</div>\n")
+	}
+
+	bw.WriteString("</body>\n</html>\n")
+	return bw.Flush()
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"steel_tables/internal/columns"
+)
+
+// writeJSON writes an array of row objects keyed by unit-bearing header, in
+// column order. Numeric cells are written as numbers and dashes as null.
+// Multiple tables are written as an object keyed by table name.
+func writeJSON(w io.Writer, tables []Table) error {
+	bw := bufio.NewWriter(w)
+	if len(tables) == 1 {
+		writeJSONRows(bw, tables[0], "")
+	} else {
+		bw.WriteString("{\n")
+		for i, table := range tables {
+			bw.WriteString("  ")
+			writeJSONString(bw, table.Name)
+			bw.WriteString(": ")
+			writeJSONRows(bw, table, "  ")
+			if i < len(tables)-1 {
+				bw.WriteString(",")
+			}
+			bw.WriteString("\n")
+		}
+		bw.WriteString("}")
+	}
+	bw.WriteString("\n")
+	return bw.Flush()
+}
+
+func writeJSONRows(bw *bufio.Writer, table Table, indent string) {
+	headers := table.Headers()
+	rows := table.Rows()
+	if len(rows) == 0 {
+		bw.WriteString("[]")
+		return
+	}
+	bw.WriteString("[\n")
+	for i, row := range rows {
+		bw.WriteString(indent + "  {")
+		for j, value := range row {
+			if j > 0 {
+				bw.WriteString(", ")
+			}
+			writeJSONString(bw, headers[j])
+			bw.WriteString(": ")
+			writeJSONValue(bw, value)
+		}
+		bw.WriteString("}")
+		if i < len(rows)-1 {
+			bw.WriteString(",")
+		}
+		bw.WriteString("\n")
+	}
+	bw.WriteString(indent + "]")
+}
+
+func writeJSONValue(bw *bufio.Writer, value string) {
+	if value == "-" || value == "" {
+		bw.WriteString("null")
+		return
+	}
+	if f, ok := columns.NumericValue(value); ok {
+		// Keep the table's formatting unless it is not a JSON number, as
+		// with "+5", ".5" or "0x1p3".
+		if json.Valid([]byte(value)) {
+			bw.WriteString(value)
+		} else {
+			encoded, _ := json.Marshal(f)
+			bw.Write(encoded)
+		}
+		return
+	}
+	writeJSONString(bw, value)
+}
+
+func writeJSONString(bw *bufio.Writer, s string) {
+	encoded, _ := json.Marshal(s)
+	bw.Write(encoded)
+}
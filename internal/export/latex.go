@@ -0,0 +1,90 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"steel_tables/internal/columns"
+)
+
+// latexEscaper escapes characters with special meaning in LaTeX text.
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	"&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+	"{", `\{`, "}", `\}`, "~", `\textasciitilde{}`, "^", `\textasciicircum{}`,
+	"α", `$\alpha$`, "φ", `$\phi$`, "λ", `$\lambda$`,
+)
+
+// superscripts maps the Unicode superscripts used in unit names to digits.
+var superscripts = map[rune]rune{
+	'⁰': '0', '¹': '1', '²': '2', '³': '3', '⁴': '4',
+	'⁵': '5', '⁶': '6', '⁷': '7', '⁸': '8', '⁹': '9',
+}
+
+// writeLaTeX writes longtable fragments for inclusion in a report. The
+// document preamble needs \usepackage{longtable}.
+func writeLaTeX(w io.Writer, tables []Table) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("% Requires \\usepackage{longtable}\n")
+	for _, table := range tables {
+		bw.WriteString("\n\\begin{longtable}{l" + strings.Repeat("r", len(table.Columns)) + "}\n")
+		bw.WriteString("\\caption{" + latexEscaper.Replace(table.Name) + "} \\\\\n")
+
+		var head strings.Builder
+		head.WriteString("\\hline\nSection")
+		for _, col := range table.Columns {
+			head.WriteString(" & " + latexEscaper.Replace(col.Name))
+		}
+		head.WriteString(" \\\\\n")
+		for _, col := range table.Columns {
+			head.WriteString(" & " + latexUnit(columns.Unit(col.Name)))
+		}
+		head.WriteString(" \\\\\n\\hline\n")
+
+		bw.WriteString(head.String() + "\\endfirsthead\n")
+		bw.WriteString(head.String() + "\\endhead\n")
+		bw.WriteString("\\hline\n\\endfoot\n")
+
+		for _, row := range table.Rows() {
+			for i, value := range row {
+				if i > 0 {
+					bw.WriteString(" & ")
+				}
+				bw.WriteString(latexEscaper.Replace(value))
+			}
+			bw.WriteString(" \\\\\n")
+		}
+		bw.WriteString("\\end{longtable}\n")
+	}
+	return bw.Flush()
+}
+
+// latexUnit converts a unit such as "10⁶mm⁴" to "(10$^{6}$mm$^{4}$)".
+func latexUnit(unit string) string {
+	if unit == "" {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("(")
+	inSup := false
+	for _, r := range unit {
+		digit, isSup := superscripts[r]
+		if isSup && !inSup {
+			sb.WriteString("$^{")
+		} else if !isSup && inSup {
+			sb.WriteString("}$")
+		}
+		inSup = isSup
+		if isSup {
+			sb.WriteRune(digit)
+		} else {
+			sb.WriteString(latexEscaper.Replace(string(r)))
+		}
+	}
+	if inSup {
+		sb.WriteString("}$")
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
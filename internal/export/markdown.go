@@ -0,0 +1,45 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// writeMarkdown writes GitHub pipe tables. Data columns are right-aligned.
+// Multiple tables are each preceded by a heading with the table name.
+func writeMarkdown(w io.Writer, tables []Table) error {
+	bw := bufio.NewWriter(w)
+	for i, table := range tables {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		if len(tables) > 1 {
+			bw.WriteString("## " + table.Name + "\n\n")
+		}
+
+		headers := table.Headers()
+		writeMarkdownRow(bw, headers)
+		bw.WriteString("|")
+		for j := range headers {
+			if j == 0 {
+				bw.WriteString(" :--- |")
+			} else {
+				bw.WriteString(" ---: |")
+			}
+		}
+		bw.WriteString("\n")
+		for _, row := range table.Rows() {
+			writeMarkdownRow(bw, row)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeMarkdownRow(bw *bufio.Writer, values []string) {
+	bw.WriteString("|")
+	for _, value := range values {
+		bw.WriteString(" " + strings.ReplaceAll(value, "|", `\|`) + " |")
+	}
+	bw.WriteString("\n")
+}
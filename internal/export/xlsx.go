@@ -0,0 +1,223 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"steel_tables/internal/columns"
+)
+
+// Cell style indexes into the cellXfs list in xlsxStyles.
+const (
+	styleDefault = 0
+	styleHeader  = 1
+	styleUnits   = 2
+)
+
+// maxSheetName is Excel's limit on worksheet name length.
+const maxSheetName = 31
+
+const xlsxContentTypesHead = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>
+`
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="3">
+<font><sz val="11"/><name val="Calibri"/></font>
+<font><b/><sz val="11"/><name val="Calibri"/></font>
+<font><i/><sz val="11"/><color rgb="FF595959"/><name val="Calibri"/></font>
+</fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="3">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="0" fontId="2" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+</cellXfs>
+<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>
+</styleSheet>
+`
+
+// writeXLSX writes an Excel workbook with one worksheet per table. Each sheet
+// has a bold header row and a units row, both frozen along with the Section
+// column. Numeric cells are stored as numbers and dashes are left empty.
+func writeXLSX(w io.Writer, tables []Table) error {
+	zw := zip.NewWriter(w)
+	names := sheetNames(tables)
+
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(xlsxContentTypesHead)
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+`)
+	workbookRels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+`)
+
+	for i := range tables {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`+"\n", n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`+"\n", escapeXML(names[i]), n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`+"\n", n, n)
+	}
+
+	contentTypes.WriteString("</Types>\n")
+	workbook.WriteString("</sheets>\n</workbook>\n")
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`+"\n", len(tables)+1)
+	workbookRels.WriteString("</Relationships>\n")
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, table := range tables {
+		parts = append(parts, struct{ name, body string }{
+			fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheetXML(table),
+		})
+	}
+	for _, part := range parts {
+		if err := writeZipFile(zw, part.name, part.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func worksheetXML(table Table) string {
+	names := []string{"Section"}
+	units := []string{""}
+	for _, col := range table.Columns {
+		names = append(names, col.Name)
+		units = append(units, columns.Unit(col.Name))
+	}
+	rows := table.Rows()
+
+	widths := make([]int, len(names))
+	for i := range names {
+		widths[i] = max(utf8.RuneCountInString(names[i]), utf8.RuneCountInString(units[i]))
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(value))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+`)
+	fmt.Fprintf(&sb, `<dimension ref="A1:%s%d"/>`+"\n", columnLetters(len(names)-1), len(rows)+2)
+	sb.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+		`<pane xSplit="1" ySplit="2" topLeftCell="B3" activePane="bottomRight" state="frozen"/>` +
+		`<selection pane="bottomRight" activeCell="B3" sqref="B3"/>` +
+		"</sheetView></sheetViews>\n")
+
+	sb.WriteString("<cols>")
+	for i, width := range widths {
+		fmt.Fprintf(&sb, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width+3)
+	}
+	sb.WriteString("</cols>\n<sheetData>\n")
+
+	writeSheetRow(&sb, 1, names, styleHeader, false)
+	writeSheetRow(&sb, 2, units, styleUnits, false)
+	for i, row := range rows {
+		writeSheetRow(&sb, i+3, row, styleDefault, true)
+	}
+
+	sb.WriteString("</sheetData>\n</worksheet>\n")
+	return sb.String()
+}
+
+// writeSheetRow writes one <row>. When numeric is set, cells that parse as
+// numbers are stored as numbers rather than text.
+func writeSheetRow(sb *strings.Builder, rowNum int, values []string, style int, numeric bool) {
+	fmt.Fprintf(sb, `<row r="%d">`, rowNum)
+	for i, value := range values {
+		if value == "" || value == "-" {
+			continue
+		}
+		ref := fmt.Sprintf("%s%d", columnLetters(i), rowNum)
+		styleAttr := ""
+		if style != styleDefault {
+			styleAttr = fmt.Sprintf(` s="%d"`, style)
+		}
+		if _, ok := columns.NumericValue(value); ok && numeric && i > 0 {
+			fmt.Fprintf(sb, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, value)
+		} else {
+			fmt.Fprintf(sb, `<c r="%s"%s t="inlineStr"><is><t>%s</t></is></c>`, ref, styleAttr, escapeXML(value))
+		}
+	}
+	sb.WriteString("</row>\n")
+}
+
+// sheetNames returns unique worksheet names within Excel's length limit.
+func sheetNames(tables []Table) []string {
+	used := make(map[string]bool)
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		base := strings.NewReplacer("/", "_", "\\", "_", "?", "_", "*", "_", "[", "_", "]", "_", ":", "_").Replace(table.Name)
+		if base == "" {
+			base = "Sheet"
+		}
+		if len(base) > maxSheetName {
+			base = base[:maxSheetName]
+		}
+		name := base
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = base
+			if len(name)+len(suffix) > maxSheetName {
+				name = name[:maxSheetName-len(suffix)]
+			}
+			name += suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+// columnLetters converts a zero-based column index to A, B, ..., Z, AA, ...
+func columnLetters(index int) string {
+	letters := ""
+	for index >= 0 {
+		letters = string(rune('A'+index%26)) + letters
+		index = index/26 - 1
+	}
+	return letters
+}
+
+func writeZipFile(zw *zip.Writer, name, body string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, body)
+	return err
+}
+
+func escapeXML(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
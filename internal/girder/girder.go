@@ -0,0 +1,266 @@
+// Package girder generates the properties of welded plate girders built
+// from three plates, so that sizes outside the WB and WC tables can be
+// viewed, exported and checked like catalog rows.
+//
+// A girder designation lists the plates as width x thickness in mm, top
+// flange first, followed by "PG":
+//
+//	500x32-1200x16-400x25PG  500x32 top flange, 1200x16 web, 400x25 bottom flange
+//	450x25-900x10PG          equal 450x25 flanges on a 900x10 web
+//
+// The top flange is taken as the compression flange for major axis bending.
+package girder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/classify"
+	"steel_tables/internal/materials"
+	"steel_tables/internal/models"
+)
+
+// DefaultGrade is the plate grade used when none is given.
+const DefaultGrade = 300
+
+// Plate is a rectangular plate (mm).
+type Plate struct {
+	B float64 // Width, or height for the web
+	T float64 // Thickness
+}
+
+func (p Plate) String() string {
+	return fmt.Sprintf("%sx%s", formatMM(p.B), formatMM(p.T))
+}
+
+// Girder is a welded I-section of three plates.
+type Girder struct {
+	Top    Plate
+	Web    Plate
+	Bottom Plate
+	Grade  int
+}
+
+// IsDesignation reports whether a designation names a plate girder.
+func IsDesignation(designation string) bool {
+	d := normalize(designation)
+	return strings.HasSuffix(d, "PG") && strings.Contains(d, "X") && !strings.Contains(d, "/")
+}
+
+// Parse reads a girder designation. A grade suffix such as "(G350)" sets
+// the grade; otherwise grade is used, or DefaultGrade if it is 0.
+func Parse(designation string, grade int) (Girder, error) {
+	designation, suffix, err := models.ParseGrade(designation)
+	if err != nil {
+		return Girder{}, err
+	}
+	if suffix != 0 {
+		grade = suffix
+	}
+	if grade == 0 {
+		grade = DefaultGrade
+	}
+	d := strings.TrimSuffix(normalize(designation), "PG")
+	parts := strings.Split(d, "-")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Girder{}, fmt.Errorf("invalid girder '%s' (want TOP-WEB[-BOTTOM]PG, e.g. 500x32-1200x16-400x25PG)", designation)
+	}
+	plates := make([]Plate, len(parts))
+	for i, part := range parts {
+		p, err := parsePlate(part)
+		if err != nil {
+			return Girder{}, fmt.Errorf("girder '%s': %w", designation, err)
+		}
+		plates[i] = p
+	}
+	g := Girder{Top: plates[0], Web: plates[1], Bottom: plates[0], Grade: grade}
+	if len(plates) == 3 {
+		g.Bottom = plates[2]
+	}
+	return g, g.Validate()
+}
+
+func parsePlate(s string) (Plate, error) {
+	dims := strings.Split(s, "X")
+	if len(dims) != 2 {
+		return Plate{}, fmt.Errorf("invalid plate '%s' (want WIDTHxTHICKNESS)", strings.ToLower(s))
+	}
+	b, errB := strconv.ParseFloat(dims[0], 64)
+	t, errT := strconv.ParseFloat(dims[1], 64)
+	if errB != nil || errT != nil {
+		return Plate{}, fmt.Errorf("invalid plate '%s' (want WIDTHxTHICKNESS)", strings.ToLower(s))
+	}
+	return Plate{B: b, T: t}, nil
+}
+
+func normalize(designation string) string {
+	designation, _ = models.SplitGrade(designation)
+	return strings.ToUpper(strings.ReplaceAll(designation, " ", ""))
+}
+
+// Validate checks the plates are positive, the web fits within the flanges
+// and the grade is an AS/NZS 3678 plate grade covering their thicknesses.
+func (g Girder) Validate() error {
+	for _, p := range []struct {
+		name  string
+		plate Plate
+	}{{"top flange", g.Top}, {"web", g.Web}, {"bottom flange", g.Bottom}} {
+		if p.plate.B <= 0 || p.plate.T <= 0 {
+			return fmt.Errorf("%s %s must have a positive size", p.name, p.plate)
+		}
+	}
+	if g.Web.T >= g.Top.B || g.Web.T >= g.Bottom.B {
+		return fmt.Errorf("web thickness %s mm must be less than the flange widths", formatMM(g.Web.T))
+	}
+	grade, err := materials.Find(strconv.Itoa(g.Grade), materials.Plate)
+	if err != nil {
+		return err
+	}
+	for _, p := range []Plate{g.Top, g.Web, g.Bottom} {
+		if !grade.Covers(p.T) {
+			return fmt.Errorf("%s mm plate is thicker than %s covers", formatMM(p.T), grade)
+		}
+	}
+	return nil
+}
+
+// Name returns the girder designation with its grade suffix.
+func (g Girder) Name() string {
+	plates := g.Top.String() + "-" + g.Web.String()
+	if g.Bottom != g.Top {
+		plates += "-" + g.Bottom.String()
+	}
+	return fmt.Sprintf("%sPG (G%d)", plates, g.Grade)
+}
+
+// Property returns the full set of section properties for the girder, in
+// the units of the catalog tables.
+func (g Girder) Property() (models.SteelProperty, error) {
+	if err := g.Validate(); err != nil {
+		return models.SteelProperty{}, err
+	}
+	top, web, bot := g.Top, g.Web, g.Bottom
+	d := top.T + web.B + bot.T
+	at, aw, ab := top.B*top.T, web.B*web.T, bot.B*bot.T
+	ag := at + aw + ab
+
+	// Plate centroids from the underside of the bottom flange.
+	yt, yw, yb := d-top.T/2, bot.T+web.B/2, bot.T/2
+	yc := (at*yt + aw*yw + ab*yb) / ag
+
+	ix := top.B*math.Pow(top.T, 3)/12 + at*math.Pow(yt-yc, 2) +
+		web.T*math.Pow(web.B, 3)/12 + aw*math.Pow(yw-yc, 2) +
+		bot.B*math.Pow(bot.T, 3)/12 + ab*math.Pow(yb-yc, 2)
+	ict := top.T * math.Pow(top.B, 3) / 12
+	icb := bot.T * math.Pow(bot.B, 3) / 12
+	iy := ict + web.B*math.Pow(web.T, 3)/12 + icb
+	zx := ix / math.Max(yc, d-yc)
+	zy := iy / (math.Max(top.B, bot.B) / 2)
+	sx := plasticModulus(top, web, bot)
+	sy := (top.T*top.B*top.B + web.B*web.T*web.T + bot.T*bot.B*bot.B) / 4
+
+	j := top.B*math.Pow(top.T, 3)/3*(1-0.63*top.T/top.B) +
+		web.B*math.Pow(web.T, 3)/3 +
+		bot.B*math.Pow(bot.T, 3)/3*(1-0.63*bot.T/bot.B)
+	df := d - top.T/2 - bot.T/2
+	iw := ict * icb / (ict + icb) * df * df
+
+	grade, _ := materials.Find(strconv.Itoa(g.Grade), materials.Plate)
+	fyt, fyw, fyb := grade.Yield(top.T), grade.Yield(web.T), grade.Yield(bot.T)
+	// Plate girders are heavily welded (HW) for Table 5.2.
+	outstand := func(name string, f Plate, fy float64, lim classify.Limits) classify.Element {
+		return classify.Flat(name, (f.B-web.T)/2, f.T, fy, lim)
+	}
+	topFlange := outstand("top flange", top, fyt, classify.OutstandUniform["HW"])
+	botFlange := outstand("bottom flange", bot, fyb, classify.OutstandUniform["HW"])
+	x := classify.Bending(zx, sx, topFlange, classify.Flat("web", web.B, web.T, fyw, classify.WebBending))
+	// Minor axis bending puts the flange tips in compression with zero
+	// stress at the web.
+	y := classify.Bending(zy, sy,
+		outstand("top flange", top, fyt, classify.OutstandGradient["HW"]),
+		outstand("bottom flange", bot, fyb, classify.OutstandGradient["HW"]))
+
+	// kf: both outstands of each flange and the web in uniform compression.
+	webAxial := classify.Flat("web", web.B, web.T, fyw, classify.SupportedUniform["HW"])
+	ae := ag
+	for _, e := range []classify.Element{topFlange, botFlange} {
+		ae -= 2 * classify.Ineffective(e.Width, e.Thickness, e.Lambda, e.Yield)
+	}
+	ae -= classify.Ineffective(webAxial.Width, webAxial.Thickness, webAxial.Lambda, webAxial.Yield)
+
+	p := models.SteelProperty{
+		Section:  g.Name(),
+		Grade:    g.Grade,
+		Weight:   models.Round(ag*models.SteelDensity, 1),
+		D:        d,
+		Bf:       top.B,
+		Tf:       top.T,
+		Tw:       web.T,
+		R1:       0.0,
+		D1:       web.B,
+		Tw1:      models.Round(web.B/web.T, 1),
+		TwoTf:    models.Round((top.B-web.T)/2/top.T, 2),
+		Ag:       models.Round(ag, 0),
+		Ix:       models.Round(ix/1e6, 1),
+		Zx:       models.Round(zx/1e3, 0),
+		Sx:       models.Round(sx/1e3, 0),
+		Rx:       models.Round(math.Sqrt(ix/ag), 1),
+		Iy:       models.Round(iy/1e6, 2),
+		Zy:       models.Round(zy/1e3, 0),
+		Sy:       models.Round(sy/1e3, 0),
+		Ry:       models.Round(math.Sqrt(iy/ag), 1),
+		J:        models.Round(j/1e3, 0),
+		Iw:       models.Round(iw/1e9, 0),
+		Flange:   math.Min(fyt, fyb),
+		Web:      fyw,
+		Kf:       models.Round(ae/ag, 3),
+		CNS:      string(x.Class),
+		Zex:      models.Round(x.Ze/1e3, 0),
+		CNS2:     string(y.Class),
+		Zey:      models.Round(y.Ze/1e3, 0),
+		Fu:       math.Min(grade.Tensile(top.T), math.Min(grade.Tensile(web.T), grade.Tensile(bot.T))),
+		PB:       models.Round(yc, 1),
+		PT:       models.Round(d-yc, 1),
+		Residual: "HW",
+	}
+	if bot != top {
+		p.Bf2, p.Tf2 = bot.B, bot.T
+	}
+	return p, nil
+}
+
+// plasticModulus returns S (mm³) about the horizontal axis that halves the
+// area, working up the plates from the bottom flange.
+func plasticModulus(top, web, bot Plate) float64 {
+	type strip struct{ y0, y1, width float64 }
+	strips := []strip{
+		{0, bot.T, bot.B},
+		{bot.T, bot.T + web.B, web.T},
+		{bot.T + web.B, bot.T + web.B + top.T, top.B},
+	}
+	half := (top.B*top.T + web.B*web.T + bot.B*bot.T) / 2
+	axis, below := 0.0, 0.0
+	for _, s := range strips {
+		a := (s.y1 - s.y0) * s.width
+		if below+a >= half {
+			axis = s.y0 + (half-below)/s.width
+			break
+		}
+		below += a
+	}
+	sum := 0.0
+	for _, s := range strips {
+		for _, part := range [][2]float64{{s.y0, math.Min(s.y1, axis)}, {math.Max(s.y0, axis), s.y1}} {
+			if part[1] > part[0] {
+				sum += (part[1] - part[0]) * s.width * math.Abs((part[0]+part[1])/2-axis)
+			}
+		}
+	}
+	return sum
+}
+
+func formatMM(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,288 @@
+// Package hollow computes the properties of cold-formed RHS, SHS and CHS
+// of any size, in the layout of the catalog hollow section tables.
+//
+// Designations follow the tables: "250 x 150 x 6.0 RHS", "89 x 3.5 SHS" and
+// "114.3 x 4.5 CHS" (depth x width x thickness, width x thickness and
+// diameter x thickness in mm). RHS and SHS corners have an outside radius of
+// 2t for t ≤ 3 mm and 2.5t above, as for AS/NZS 1163 sections, unless one is
+// given.
+package hollow
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/classify"
+	"steel_tables/internal/materials"
+	"steel_tables/internal/models"
+)
+
+// DefaultGrade is the AS/NZS 1163 grade used when none is given.
+const DefaultGrade = 350
+
+// Shape is the kind of hollow section.
+type Shape string
+
+const (
+	RHS Shape = "RHS"
+	SHS Shape = "SHS"
+	CHS Shape = "CHS"
+)
+
+// Section is a hollow section's dimensions (mm) and grade.
+type Section struct {
+	Shape  Shape
+	D      float64 // Depth, or outside diameter for CHS
+	B      float64 // Width; equal to D for SHS and CHS
+	T      float64 // Wall thickness
+	Radius float64 // Outside corner radius; 0 for the default
+	Grade  int
+}
+
+// Parse reads a designation such as "250 x 150 x 6 RHS", "100 x 5 SHS" (or
+// "100 x 100 x 5 SHS") or "168.3 x 6.4 CHS". A grade suffix
+// such as "(G450)" sets the grade; otherwise grade is used, or DefaultGrade
+// if it is 0.
+func Parse(designation string, grade int) (Section, error) {
+	designation, suffix, err := models.ParseGrade(designation)
+	if err != nil {
+		return Section{}, err
+	}
+	if suffix != 0 {
+		grade = suffix
+	}
+	if grade == 0 {
+		grade = DefaultGrade
+	}
+	d := strings.ToUpper(strings.ReplaceAll(strings.TrimRight(strings.TrimSpace(designation), "#*"), " ", ""))
+	var s Section
+	for _, shape := range []Shape{RHS, SHS, CHS} {
+		if strings.HasSuffix(d, string(shape)) {
+			s.Shape = shape
+			d = strings.TrimSuffix(d, string(shape))
+		}
+	}
+	if s.Shape == "" {
+		return Section{}, fmt.Errorf("invalid hollow section '%s' (want RHS, SHS or CHS)", designation)
+	}
+	var dims []float64
+	for _, part := range strings.Split(d, "X") {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return Section{}, fmt.Errorf("invalid dimension '%s' in '%s'", part, designation)
+		}
+		dims = append(dims, v)
+	}
+	if s.Shape == SHS && len(dims) == 3 {
+		// "B x B x t", as the SHS tables write it.
+		if dims[0] != dims[1] {
+			return Section{}, fmt.Errorf("SHS sides differ in '%s'; use RHS", designation)
+		}
+		dims = dims[1:]
+	}
+	want := 2
+	if s.Shape == RHS {
+		want = 3
+	}
+	if len(dims) != want {
+		return Section{}, fmt.Errorf("%s needs %d dimensions in '%s'", s.Shape, want, designation)
+	}
+	s.D, s.B, s.T = dims[0], dims[0], dims[len(dims)-1]
+	if s.Shape == RHS {
+		s.B = dims[1]
+	}
+	s.Grade = grade
+	return s, s.Validate()
+}
+
+// Validate checks the dimensions and grade.
+func (s Section) Validate() error {
+	if s.D <= 0 || s.B <= 0 || s.T <= 0 {
+		return fmt.Errorf("dimensions must be positive")
+	}
+	if 2*s.T >= math.Min(s.D, s.B) {
+		return fmt.Errorf("wall thickness %g mm is too thick for a %g mm section", s.T, math.Min(s.D, s.B))
+	}
+	if s.Radius != 0 && (s.Radius < s.T || 2*s.Radius > math.Min(s.D, s.B)) {
+		return fmt.Errorf("corner radius %g mm must be between t and half the width", s.Radius)
+	}
+	if _, err := s.Material(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Material returns the section's AS/NZS 1163 grade.
+func (s Section) Material() (materials.Grade, error) {
+	return materials.Find(strconv.Itoa(s.Grade), materials.ColdFormed)
+}
+
+func (s Section) yieldStress() float64 {
+	grade, _ := s.Material()
+	return grade.Yield(s.T)
+}
+
+// CornerRadius returns the outside corner radius in mm.
+func (s Section) CornerRadius() float64 {
+	switch {
+	case s.Shape == CHS:
+		return 0
+	case s.Radius > 0:
+		return s.Radius
+	case s.T <= 3:
+		return 2 * s.T
+	}
+	return 2.5 * s.T
+}
+
+// Name returns the designation in the catalog's format with a grade suffix.
+func (s Section) Name() string {
+	t := strconv.FormatFloat(s.T, 'f', 1, 64)
+	switch s.Shape {
+	case RHS:
+		return fmt.Sprintf("%g x %g x %s RHS (G%d)", s.D, s.B, t, s.Grade)
+	case SHS:
+		return fmt.Sprintf("%g x %s SHS (G%d)", s.D, t, s.Grade)
+	}
+	return fmt.Sprintf("%.1f x %s CHS (G%d)", s.D, t, s.Grade)
+}
+
+// Property returns the section's properties, in the units of the catalog
+// tables. J uses the thin-walled closed-section formula and C is the
+// torsion modulus.
+func (s Section) Property() (models.SteelProperty, error) {
+	if err := s.Validate(); err != nil {
+		return models.SteelProperty{}, err
+	}
+	if s.Shape == CHS {
+		return s.circular(), nil
+	}
+	return s.rectangular(), nil
+}
+
+func (s Section) rectangular() models.SteelProperty {
+	d, b, t := s.D, s.B, s.T
+	ro := s.CornerRadius()
+	ri := ro - t
+	fy := s.yieldStress()
+
+	ag, ix, sx := roundedRect(b, d, ro)
+	a2, ix2, sx2 := roundedRect(b-2*t, d-2*t, ri)
+	ag, ix, sx = ag-a2, ix-ix2, sx-sx2
+	_, iy, sy := roundedRect(d, b, ro)
+	_, iy2, sy2 := roundedRect(d-2*t, b-2*t, ri)
+	iy, sy = iy-iy2, sy-sy2
+	zx, zy := ix/(d/2), iy/(b/2)
+
+	// Bredt's formula on the wall centreline.
+	rm := ro - t/2
+	enclosed := (b-t)*(d-t) - (4-math.Pi)*rm*rm
+	perimeter := 2*((b-t)+(d-t)) - 2*(4-math.Pi)*rm
+	j := 4*enclosed*enclosed*t/perimeter + perimeter*t*t*t/3
+	c := j / (t + 2*enclosed/perimeter)
+
+	// Flat widths between the walls, as for Table 5.2, with cold-formed (CF)
+	// limits. Moduli are passed to classify in 10³mm³ and 10⁶mm⁴.
+	bw, dw := b-2*t, d-2*t
+	wall := func(name string, w float64, lim classify.Limits) classify.Element {
+		return classify.Flat(name, w, t, fy, lim)
+	}
+	flange, side := wall("flange", bw, classify.SupportedUniform["CF"]), wall("web", dw, classify.SupportedUniform["CF"])
+	x := classify.RectangularBending(ag, ix/1e6, zx/1e3, sx/1e3, d, flange, wall("web", dw, classify.WebBending))
+	y := classify.RectangularBending(ag, iy/1e6, zy/1e3, sy/1e3, b, side, wall("flange", bw, classify.WebBending))
+
+	// kf with all four walls in uniform compression.
+	ineffective := 2*classify.Ineffective(bw, t, flange.Lambda, flange.Yield) +
+		2*classify.Ineffective(dw, t, side.Lambda, side.Yield)
+
+	p := s.common(ag, ix, iy, sx, sy, zx, zy, j, c)
+	p.R1 = ro
+	p.TwoTf = models.Round(bw/t, 1)
+	p.Tw1 = models.Round(dw/t, 1)
+	p.Kf = models.Round(1-ineffective/ag, 3)
+	p.CNS, p.Zex = string(x.Class), models.Round(x.Ze, 0)
+	p.CNS2, p.Zey = string(y.Class), models.Round(y.Ze, 0)
+	return p
+}
+
+func (s Section) circular() models.SteelProperty {
+	do, t := s.D, s.T
+	di := do - 2*t
+	fy := s.yieldStress()
+
+	ag := math.Pi / 4 * (do*do - di*di)
+	i := math.Pi / 64 * (math.Pow(do, 4) - math.Pow(di, 4))
+	z := i / (do / 2)
+	sp := (math.Pow(do, 3) - math.Pow(di, 3)) / 6
+	j := 2 * i
+	c := 2 * z
+
+	wall := classify.Wall(do, t, fy, classify.Circular["CF"])
+	a := classify.CircularBending(z, sp, wall)
+	class, ze := string(a.Class), a.Ze
+	kf := classify.CircularFormFactor(wall)
+
+	p := s.common(ag, i, i, sp, sp, z, z, j, c)
+	p.Tw1 = models.Round(do/t, 1)
+	p.Kf = models.Round(kf, 3)
+	p.CNS, p.Zex = class, models.Round(ze/1e3, 0)
+	p.CNS2, p.Zey = class, models.Round(ze/1e3, 0)
+	return p
+}
+
+// common fills the properties shared by every shape from values in mm.
+func (s Section) common(ag, ix, iy, sx, sy, zx, zy, j, c float64) models.SteelProperty {
+	grade, _ := s.Material()
+	fy := grade.Yield(s.T)
+	return models.SteelProperty{
+		Section:  s.Name(),
+		Grade:    s.Grade,
+		Weight:   models.Round(ag*models.SteelDensity, 2),
+		D:        s.D,
+		Bf:       s.B,
+		Tf:       s.T,
+		Tw:       s.T,
+		R1:       "-",
+		D1:       s.D - 2*s.T,
+		Tw1:      "-",
+		TwoTf:    "-",
+		Ag:       models.Round(ag, 0),
+		Ix:       models.Round(ix/1e6, 3),
+		Zx:       models.Round(zx/1e3, 1),
+		Sx:       models.Round(sx/1e3, 1),
+		Rx:       models.Round(math.Sqrt(ix/ag), 1),
+		Iy:       models.Round(iy/1e6, 3),
+		Zy:       models.Round(zy/1e3, 1),
+		Sy:       models.Round(sy/1e3, 1),
+		Ry:       models.Round(math.Sqrt(iy/ag), 1),
+		J:        models.Round(j/1e3, 0),
+		C:        models.Round(c/1e3, 1),
+		Iw:       0.0,
+		Flange:   fy,
+		Web:      fy,
+		AlphaB:   -0.5,
+		Fu:       grade.Tensile(s.T),
+		Residual: "CF",
+	}
+}
+
+// roundedRect returns the area, second moment of area and plastic modulus
+// about the horizontal axis of a solid b x d rectangle with corners of
+// radius r.
+func roundedRect(b, d, r float64) (area, i, s float64) {
+	// Each corner removes a spandrel: an r x r square less a quarter circle.
+	spandrel := (1 - math.Pi/4) * r * r
+	edge := 0.0 // Centroid distance from the outer edges
+	if r > 0 {
+		edge = r * (10 - 3*math.Pi) / (3 * (4 - math.Pi))
+	}
+	ownI := math.Pow(r, 4)*(1-5*math.Pi/16) - spandrel*edge*edge
+	arm := d/2 - edge
+
+	area = b*d - 4*spandrel
+	i = b*d*d*d/12 - 4*(ownI+spandrel*arm*arm)
+	s = b*d*d/4 - 4*spandrel*arm
+	return area, i, s
+}
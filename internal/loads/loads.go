@@ -0,0 +1,219 @@
+// Package loads generates AS/NZS 1170.0 load combinations from named load
+// cases.
+//
+// Load cases are recognised by their name prefix: G (permanent), Q
+// (imposed), Wu (ultimate wind), Ws (serviceability wind) and Eu (ultimate
+// earthquake). Several cases of the same kind, such as G1 and G2, act
+// together; several wind or earthquake cases, such as Wu1 and Wu2 for
+// different directions, each get their own combinations.
+package loads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kind is the type of action a load case represents.
+type Kind int
+
+const (
+	Permanent Kind = iota
+	Imposed
+	WindUltimate
+	WindService
+	Earthquake
+)
+
+// KindOf returns the kind of a load case from its name.
+func KindOf(name string) (Kind, bool) {
+	upper := strings.ToUpper(name)
+	switch {
+	case strings.HasPrefix(upper, "WU"):
+		return WindUltimate, true
+	case strings.HasPrefix(upper, "WS"):
+		return WindService, true
+	case strings.HasPrefix(upper, "EU"):
+		return Earthquake, true
+	case strings.HasPrefix(upper, "G"):
+		return Permanent, true
+	case strings.HasPrefix(upper, "Q"):
+		return Imposed, true
+	}
+	return Permanent, false
+}
+
+// LimitState separates strength from serviceability combinations.
+type LimitState int
+
+const (
+	Strength LimitState = iota
+	Serviceability
+)
+
+func (s LimitState) String() string {
+	if s == Serviceability {
+		return "serviceability"
+	}
+	return "strength"
+}
+
+// Occupancy holds the AS/NZS 1170.0 Table 4.1 combination factors for the
+// imposed action.
+type Occupancy struct {
+	Name string
+	PsiS float64 // Short-term factor ψs
+	PsiL float64 // Long-term factor ψl
+	PsiC float64 // Combination factor ψc
+}
+
+// Occupancies lists the Table 4.1 factors by occupancy.
+var Occupancies = []Occupancy{
+	{"residential", 0.7, 0.4, 0.4},
+	{"office", 0.7, 0.4, 0.4},
+	{"parking", 0.7, 0.4, 0.4},
+	{"retail", 0.7, 0.4, 0.4},
+	{"storage", 1.0, 0.6, 0.6},
+	{"other", 1.0, 0.6, 0.6},
+	{"roof", 0.7, 0.0, 0.0},
+}
+
+// ParseOccupancy looks up an occupancy by name.
+func ParseOccupancy(name string) (Occupancy, error) {
+	for _, o := range Occupancies {
+		if strings.EqualFold(o.Name, name) {
+			return o, nil
+		}
+	}
+	names := make([]string, len(Occupancies))
+	for i, o := range Occupancies {
+		names[i] = o.Name
+	}
+	return Occupancy{}, fmt.Errorf("invalid occupancy '%s' (want %s)", name, strings.Join(names, ", "))
+}
+
+// Factor is a load case and the factor applied to it.
+type Factor struct {
+	Case   string
+	Factor float64
+}
+
+// Combination is a factored sum of load cases.
+type Combination struct {
+	Name    string
+	State   LimitState
+	Clause  string
+	Factors []Factor
+}
+
+// Factor returns the factor applied to a load case, or 0 if it is not part
+// of the combination.
+func (c Combination) Factor(loadCase string) float64 {
+	for _, f := range c.Factors {
+		if f.Case == loadCase {
+			return f.Factor
+		}
+	}
+	return 0
+}
+
+// String formats the combination as, for example, "1.2G + 1.5Q".
+func (c Combination) String() string {
+	parts := make([]string, len(c.Factors))
+	for i, f := range c.Factors {
+		if f.Factor == 1 {
+			parts[i] = f.Case
+		} else {
+			parts[i] = fmt.Sprintf("%g%s", f.Factor, f.Case)
+		}
+	}
+	return strings.Join(parts, " + ")
+}
+
+// Generate returns the strength combinations of AS/NZS 1170.0 Cl. 4.2.2 and
+// the usual serviceability combinations for the given load cases. Cases
+// with unrecognised names are an error.
+func Generate(cases []string, occ Occupancy) ([]Combination, error) {
+	byKind := map[Kind][]string{}
+	for _, c := range cases {
+		k, ok := KindOf(c)
+		if !ok {
+			return nil, fmt.Errorf("load case '%s' is not G, Q, Wu, Ws or Eu", c)
+		}
+		byKind[k] = append(byKind[k], c)
+	}
+	for _, names := range byKind {
+		sort.Strings(names)
+	}
+	g, q := byKind[Permanent], byKind[Imposed]
+
+	var combos []Combination
+	add := func(state LimitState, clause string, parts ...[]Factor) {
+		var factors []Factor
+		for _, p := range parts {
+			for _, f := range p {
+				if f.Factor != 0 {
+					factors = append(factors, f)
+				}
+			}
+		}
+		if len(factors) == 0 {
+			return
+		}
+		prefix := "ULS"
+		if state == Serviceability {
+			prefix = "SLS"
+		}
+		n := 1
+		for _, c := range combos {
+			if c.State == state {
+				n++
+			}
+		}
+		combos = append(combos, Combination{
+			Name:    fmt.Sprintf("%s%d", prefix, n),
+			State:   state,
+			Clause:  clause,
+			Factors: factors,
+		})
+	}
+	scaled := func(names []string, factor float64) []Factor {
+		f := make([]Factor, len(names))
+		for i, n := range names {
+			f[i] = Factor{n, factor}
+		}
+		return f
+	}
+
+	// Strength, Cl. 4.2.2.
+	if len(g) > 0 {
+		add(Strength, "4.2.2(a)", scaled(g, 1.35))
+	}
+	if len(q) > 0 {
+		add(Strength, "4.2.2(b)", scaled(g, 1.2), scaled(q, 1.5))
+	}
+	for _, w := range byKind[WindUltimate] {
+		add(Strength, "4.2.2(d)", scaled(g, 1.2), scaled(q, occ.PsiC), []Factor{{w, 1}})
+		add(Strength, "4.2.2(e)", scaled(g, 0.9), []Factor{{w, 1}})
+	}
+	for _, e := range byKind[Earthquake] {
+		add(Strength, "4.2.2(f)", scaled(g, 1), scaled(q, occ.PsiC), []Factor{{e, 1}})
+	}
+
+	// Serviceability, Cl. 4.3.
+	if len(g) > 0 {
+		add(Serviceability, "4.3 G", scaled(g, 1))
+	}
+	if len(q) > 0 {
+		add(Serviceability, "4.3 short-term", scaled(g, 1), scaled(q, occ.PsiS))
+		if occ.PsiL > 0 {
+			add(Serviceability, "4.3 long-term", scaled(g, 1), scaled(q, occ.PsiL))
+		}
+		add(Serviceability, "4.3 Q", scaled(q, 1))
+	}
+	for _, w := range byKind[WindService] {
+		add(Serviceability, "4.3 wind", []Factor{{w, 1}})
+		add(Serviceability, "4.3 wind", scaled(g, 1), scaled(q, occ.PsiL), []Factor{{w, 1}})
+	}
+	return combos, nil
+}
@@ -0,0 +1,204 @@
+// Package materials holds the structural steel grades of the Australian
+// product standards with their yield stress and tensile strength by
+// thickness, and works out the material properties of a section from its
+// grade and element thicknesses.
+//
+// Hot-rolled sections (UB, UC, PFC, angles and the tees cut from them) are
+// AS/NZS 3679.1, welded sections (WB, WC, plate girders and their tees) are
+// made from AS/NZS 3678 plate, and hollow sections are cold-formed to
+// AS/NZS 1163.
+package materials
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/models"
+)
+
+// Standard is a steel product standard.
+type Standard string
+
+const (
+	HotRolled  Standard = "AS/NZS 3679.1" // Hot-rolled bars and sections
+	Plate      Standard = "AS/NZS 3678"   // Hot-rolled plate
+	ColdFormed Standard = "AS/NZS 1163"   // Cold-formed hollow sections
+)
+
+// Band is the minimum yield stress and tensile strength in MPa for
+// thicknesses up to and including Thickness (mm), or less than it when
+// Exclusive.
+type Band struct {
+	Thickness float64
+	Fy        float64
+	Fu        float64
+	Exclusive bool
+}
+
+// Grade is a steel grade of one product standard, with its thickness bands
+// in increasing order. The last band covers every greater thickness. The
+// first hot-rolled section band is "less than 11 mm", so an 11 mm flange of
+// grade 300 has fy = 300 MPa.
+type Grade struct {
+	Name     string
+	Standard Standard
+	Bands    []Band
+}
+
+// unlimited marks a band that covers every thickness.
+var unlimited = math.Inf(1)
+
+// grades lists the database.
+var grades = []Grade{
+	{"300", HotRolled, []Band{{11, 320, 440, true}, {17, 300, 440, false}, {unlimited, 280, 440, false}}},
+	{"300L0", HotRolled, []Band{{11, 320, 440, true}, {17, 300, 440, false}, {unlimited, 280, 440, false}}},
+	{"350", HotRolled, []Band{{11, 360, 480, true}, {40, 340, 480, false}, {unlimited, 330, 480, false}}},
+	{"300", Plate, []Band{{8, 320, 430, false}, {12, 310, 430, false}, {20, 300, 430, false}, {50, 280, 430, false}, {80, 270, 430, false}, {150, 260, 430, false}}},
+	{"350", Plate, []Band{{12, 360, 450, false}, {20, 350, 450, false}, {80, 340, 450, false}, {150, 330, 450, false}}},
+	{"400", Plate, []Band{{12, 400, 480, false}, {20, 380, 480, false}, {80, 360, 480, false}}},
+	{"450", Plate, []Band{{20, 450, 500, false}, {32, 420, 500, false}, {50, 400, 500, false}}},
+	{"C250", ColdFormed, []Band{{unlimited, 250, 320, false}}},
+	{"C350", ColdFormed, []Band{{unlimited, 350, 430, false}}},
+	{"C450", ColdFormed, []Band{{unlimited, 450, 500, false}}},
+}
+
+// Grades returns every grade in the database.
+func Grades() []Grade {
+	return append([]Grade(nil), grades...)
+}
+
+// Find returns the named grade of a standard. Names ignore case, and a
+// cold-formed grade may be given without its C.
+func Find(name string, s Standard) (Grade, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if s == ColdFormed && !strings.HasPrefix(name, "C") {
+		name = "C" + name
+	}
+	var names []string
+	for _, g := range grades {
+		if g.Standard != s {
+			continue
+		}
+		if g.Name == name {
+			return g, nil
+		}
+		names = append(names, g.Name)
+	}
+	return Grade{}, fmt.Errorf("no grade %s in %s (want %s)", name, s, strings.Join(names, ", "))
+}
+
+// Band returns the band for thickness t in mm.
+func (g Grade) Band(t float64) Band {
+	for _, b := range g.Bands {
+		if t < b.Thickness || (t == b.Thickness && !b.Exclusive) {
+			return b
+		}
+	}
+	return g.Bands[len(g.Bands)-1]
+}
+
+// Yield returns fy in MPa for thickness t in mm.
+func (g Grade) Yield(t float64) float64 {
+	return g.Band(t).Fy
+}
+
+// Tensile returns fu in MPa for thickness t in mm.
+func (g Grade) Tensile(t float64) float64 {
+	return g.Band(t).Fu
+}
+
+// Covers reports whether the standard gives properties for thickness t.
+func (g Grade) Covers(t float64) bool {
+	return t <= g.Bands[len(g.Bands)-1].Thickness
+}
+
+// String returns the grade and its standard, e.g. "300 (AS/NZS 3679.1)".
+func (g Grade) String() string {
+	return fmt.Sprintf("%s (%s)", g.Name, g.Standard)
+}
+
+// Range describes the thickness range of band i in mm.
+func (g Grade) Range(i int) string {
+	b := g.Bands[i]
+	switch {
+	case len(g.Bands) == 1:
+		return "all"
+	case i == 0 && b.Exclusive:
+		return fmt.Sprintf("< %g", b.Thickness)
+	case i == 0:
+		return fmt.Sprintf("≤ %g", b.Thickness)
+	}
+	prev := g.Bands[i-1]
+	switch {
+	case math.IsInf(b.Thickness, 1) && prev.Exclusive:
+		return fmt.Sprintf("≥ %g", prev.Thickness)
+	case math.IsInf(b.Thickness, 1):
+		return fmt.Sprintf("> %g", prev.Thickness)
+	}
+	return fmt.Sprintf("%g – %g", prev.Thickness, b.Thickness)
+}
+
+// StandardFor returns the product standard of a section family.
+func StandardFor(f models.Family) Standard {
+	switch {
+	case f.IsHollow():
+		return ColdFormed
+	case f.IsWelded() || f == models.FamilyWT:
+		return Plate
+	}
+	return HotRolled
+}
+
+// Section holds the material properties of a section: the yield stress of
+// its flanges and web from their thicknesses and the tensile strength.
+type Section struct {
+	Grade           *Grade  // nil when the grade is not in the database
+	FlangeThickness float64 // mm, the thicker flange of a monosymmetric section; 0 for table values
+	WebThickness    float64 // mm; 0 for table values
+	Flange          float64 // fy of the flanges (MPa)
+	Web             float64 // fy of the web (MPa)
+	Fu              float64 // MPa, 0 if unknown
+}
+
+// Fy returns the lower of the flange and web yield stresses, which is used
+// for section capacities.
+func (s Section) Fy() float64 {
+	if s.Flange == 0 || (s.Web != 0 && s.Web < s.Flange) {
+		return s.Web
+	}
+	return s.Flange
+}
+
+// Of returns a section's material properties from its grade and element
+// thicknesses. Sections whose grade is not in the database, and built-up
+// sections (designations with a /) whose table values are those of their
+// components, keep the table's flange and web values and any Fu.
+func Of(p models.SteelProperty) Section {
+	s := Section{FlangeThickness: math.Max(p.Tf, p.Tf2), WebThickness: p.Tw}
+	if s.WebThickness == 0 {
+		s.WebThickness = s.FlangeThickness
+	}
+	g, err := Find(strconv.Itoa(p.Grade), StandardFor(p.Family()))
+	if err == nil {
+		s.Grade = &g
+	}
+	if s.Grade != nil && s.FlangeThickness > 0 && !strings.Contains(p.Section, "/") {
+		s.Flange = g.Yield(s.FlangeThickness)
+		s.Web = g.Yield(s.WebThickness)
+		s.Fu = math.Min(g.Tensile(s.FlangeThickness), g.Tensile(s.WebThickness))
+		return s
+	}
+	s.Flange, s.Web = p.FlangeYield(), p.WebYield()
+	if s.Web == 0 {
+		s.Web = s.Flange
+	}
+	if fu, ok := p.TensileStrength(); ok && fu > 0 {
+		s.Fu = fu
+	} else if s.Grade != nil {
+		s.Fu = g.Tensile(s.FlangeThickness)
+	}
+	s.FlangeThickness, s.WebThickness = 0, 0
+	return s
+}
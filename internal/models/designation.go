@@ -0,0 +1,48 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SteelDensity is in kg/mm²/m, giving kg/m from an area in mm².
+const SteelDensity = 7850e-6
+
+// SplitGrade splits a designation such as "410UB53.7 (G350)" into its name
+// and grade suffix, "410UB53.7" and " (G350)". The suffix keeps anything
+// after the grade, such as a trailing "#", and is empty when there is none.
+func SplitGrade(designation string) (name, suffix string) {
+	idx := strings.Index(designation, "(G")
+	if idx == -1 {
+		return designation, ""
+	}
+	name = strings.TrimRight(designation[:idx], " ")
+	return name, designation[len(name):]
+}
+
+// ParseGrade returns a designation's name and the grade in its suffix, or 0
+// when it has none. The name is returned even if the grade is invalid.
+func ParseGrade(designation string) (string, int, error) {
+	name, suffix := SplitGrade(designation)
+	if suffix == "" {
+		return name, 0, nil
+	}
+	grade := strings.TrimPrefix(strings.TrimSpace(suffix), "(G")
+	if end := strings.Index(grade, ")"); end != -1 {
+		grade = grade[:end]
+	}
+	g, err := strconv.Atoi(strings.TrimSpace(grade))
+	if err != nil {
+		return name, 0, fmt.Errorf("invalid grade in '%s'", designation)
+	}
+	return name, g, nil
+}
+
+// Round rounds v to the given number of decimal places, as the catalog
+// tables are.
+func Round(v float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,75 @@
+package models
+
+import "strings"
+
+// Family identifies the kind of section a row belongs to.
+type Family string
+
+// Section families present in the catalog.
+const (
+	FamilyUnknown Family = ""
+	FamilyUB      Family = "UB"
+	FamilyUC      Family = "UC"
+	FamilyWB      Family = "WB"
+	FamilyWC      Family = "WC"
+	FamilyPFC     Family = "PFC"
+	FamilyRHS     Family = "RHS"
+	FamilySHS     Family = "SHS"
+	FamilyCHS     Family = "CHS"
+	FamilyEA      Family = "EA"
+	FamilyUA      Family = "UA"
+	FamilyPG      Family = "PG"
+	FamilyBT      Family = "BT"
+	FamilyCT      Family = "CT"
+	FamilyWT      Family = "WT"
+)
+
+// familyOrder lists families so longer codes are matched before shorter ones
+// that could appear inside them.
+var familyOrder = []Family{
+	FamilyPFC, FamilyRHS, FamilySHS, FamilyCHS,
+	FamilyUB, FamilyUC, FamilyWB, FamilyWC, FamilyEA, FamilyUA, FamilyPG,
+	FamilyBT, FamilyCT, FamilyWT,
+}
+
+// Family determines the section family from the designation.
+func (sp SteelProperty) Family() Family {
+	section, _ := SplitGrade(strings.ToUpper(sp.Section))
+	for _, f := range familyOrder {
+		if strings.Contains(section, string(f)) {
+			return f
+		}
+	}
+	return FamilyUnknown
+}
+
+// IsISection reports whether the family is an I-section. Plate girders may
+// have unequal flanges; the others are doubly symmetric.
+func (f Family) IsISection() bool {
+	return f == FamilyUB || f == FamilyUC || f == FamilyWB || f == FamilyWC || f == FamilyPG
+}
+
+// IsChannel reports whether the family is a channel.
+func (f Family) IsChannel() bool {
+	return f == FamilyPFC
+}
+
+// IsHollow reports whether the family is a hollow section.
+func (f Family) IsHollow() bool {
+	return f == FamilyRHS || f == FamilySHS || f == FamilyCHS
+}
+
+// IsAngle reports whether the family is an equal or unequal angle.
+func (f Family) IsAngle() bool {
+	return f == FamilyEA || f == FamilyUA
+}
+
+// IsTee reports whether the family is a tee cut from an I-section.
+func (f Family) IsTee() bool {
+	return f == FamilyBT || f == FamilyCT || f == FamilyWT
+}
+
+// IsWelded reports whether the family is built up from welded plate.
+func (f Family) IsWelded() bool {
+	return f == FamilyWB || f == FamilyWC || f == FamilyPG
+}
@@ -1,56 +1,72 @@
 // Package models defines data structures for steel section properties.
 package models
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
 
 // SteelProperty defines the structure for a single steel section property.
 type SteelProperty struct {
-	Section  string      `json:"Section"`
-	Grade    int         `json:"Grade"`
-	Weight   float64     `json:"Weight"`
-	D        float64     `json:"d"`
-	Bf       float64     `json:"bf"`
-	Tf       float64     `json:"tf"`
-	Tw       float64     `json:"tw"`
-	R1       interface{} `json:"r1"`
-	D1       float64     `json:"d1"`
-	Tw1      interface{} `json:"tw__1"`
-	Tf1      interface{} `json:"tf__1"`
-	Ag       float64     `json:"Ag"`
-	Ix       float64     `json:"Ix"`
-	Zx       float64     `json:"Zx"`
-	Sx       float64     `json:"Sx"`
-	Rx       float64     `json:"rx"`
-	Iy       float64     `json:"Iy"`
-	Zy       float64     `json:"Zy"`
-	Sy       float64     `json:"Sy"`
-	Ry       float64     `json:"ry"`
-	J        float64     `json:"J"`
-	Iw       interface{} `json:"Iw"`
-	Flange   interface{} `json:"flange"`
-	Web      interface{} `json:"web"`
-	Kf       interface{} `json:"kf"`
-	CNS      interface{} `json:"-"`
-	Zex      float64     `json:"Zex"`
-	CNS2     interface{} `json:"-"`
-	Zey      float64     `json:"Zey"`
-	TwoTf    interface{} `json:"2tf"`
-	Zy5      float64     `json:"Zy5"`
-	TanAlpha float64     `json:"Tan Alpha"`
-	AlphaB   interface{} `json:"αb"`
-	Fu       interface{} `json:"Fu"`
-	R2       interface{} `json:"r2"`
-	ZeyD     float64     `json:"ZeyD"`
-	In       float64     `json:"In"`
-	Ip       float64     `json:"Ip"`
-	ZexC     float64     `json:"ZexC"`
-	X5       interface{} `json:"x5"`
-	Y5       float64     `json:"y5"`
-	NL       float64     `json:"nL"`
-	PB       float64     `json:"pB"`
-	PT       interface{} `json:"pT"`
-	Residual string      `json:"Residual"`
-	Type     interface{} `json:"Type"`
+	Section   string      `json:"Section"`
+	Grade     int         `json:"Grade"`
+	Weight    float64     `json:"Weight"`
+	D         float64     `json:"d"`
+	Bf        float64     `json:"bf"`
+	Tf        float64     `json:"tf"`
+	Tw        float64     `json:"tw"`
+	R1        interface{} `json:"r1"`
+	D1        float64     `json:"d1"`
+	Tw1       interface{} `json:"tw__1"`
+	Tf1       interface{} `json:"tf__1"`
+	Bf2       float64     `json:"bf2"`
+	Tf2       float64     `json:"tf2"`
+	Ag        float64     `json:"Ag"`
+	Ix        float64     `json:"Ix"`
+	Zx        float64     `json:"Zx"`
+	ZxT       float64     `json:"ZxT"`
+	ZxB       float64     `json:"ZxB"`
+	Sx        float64     `json:"Sx"`
+	Rx        float64     `json:"rx"`
+	Iy        float64     `json:"Iy"`
+	Zy        float64     `json:"Zy"`
+	Sy        float64     `json:"Sy"`
+	Ry        float64     `json:"ry"`
+	J         float64     `json:"J"`
+	Iw        interface{} `json:"Iw"`
+	C         float64     `json:"C"`
+	Flange    interface{} `json:"flange"`
+	Web       interface{} `json:"web"`
+	Kf        interface{} `json:"kf"`
+	CNS       interface{} `json:"-"`
+	Zex       float64     `json:"Zex"`
+	CNS2      interface{} `json:"-"`
+	Zey       float64     `json:"Zey"`
+	ZeyL      float64     `json:"ZeyL"`
+	ZeyR      float64     `json:"ZeyR"`
+	TwoTf     interface{} `json:"2tf"`
+	Zy5       float64     `json:"Zy5"`
+	TanAlpha  float64     `json:"Tan Alpha"`
+	AlphaB    interface{} `json:"αb"`
+	Fu        interface{} `json:"Fu"`
+	R2        interface{} `json:"r2"`
+	ZeyD      float64     `json:"ZeyD"`
+	In        float64     `json:"In"`
+	Ip        float64     `json:"Ip"`
+	ZexC      float64     `json:"ZexC"`
+	X5        interface{} `json:"x5"`
+	Y5        float64     `json:"y5"`
+	NL        float64     `json:"nL"`
+	PB        float64     `json:"pB"`
+	PT        interface{} `json:"pT"`
+	XL        float64     `json:"xL"`
+	Xo        float64     `json:"Xo"`
+	Doubler   interface{} `json:"Doubler"`
+	Stiffener interface{} `json:"Stiffener"`
+	Residual  string      `json:"Residual"`
+	Type      interface{} `json:"Type"`
 }
 
 // UnmarshalJSON handles JSON fields with commas in their names.
@@ -76,3 +92,48 @@ func (sp *SteelProperty) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// MarshalJSON writes the row in the layout of the data files: fields in
+// struct order with each classification key before its Ze, leaving out
+// empty values.
+func (sp SteelProperty) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	write := func(key string, value interface{}) error {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(encoded)
+		return nil
+	}
+
+	v := reflect.ValueOf(sp)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		value := v.Field(i)
+		var err error
+		switch {
+		case key == "Zex" && sp.CNS != nil:
+			err = write("C,N,S", sp.CNS)
+		case key == "Zey" && sp.CNS2 != nil:
+			err = write("C,N,S__1", sp.CNS2)
+		}
+		empty := value.IsZero() && key != "Section" && key != "Grade"
+		if err == nil && key != "-" && !empty {
+			err = write(key, value.Interface())
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,79 @@
+package models
+
+import "strconv"
+
+// Number converts a loosely typed JSON value to a float64.
+// Returns false for nil, blanks, dashes and other non-numeric text.
+func Number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	}
+	return 0, false
+}
+
+// FlangeYield returns the flange yield stress in MPa, or 0 if absent.
+func (sp SteelProperty) FlangeYield() float64 {
+	f, _ := Number(sp.Flange)
+	return f
+}
+
+// WebYield returns the web yield stress in MPa, or 0 if absent.
+func (sp SteelProperty) WebYield() float64 {
+	f, _ := Number(sp.Web)
+	return f
+}
+
+// MinYield returns the lower of the flange and web yield stresses in MPa.
+func (sp SteelProperty) MinYield() float64 {
+	fyf, fyw := sp.FlangeYield(), sp.WebYield()
+	if fyf == 0 || (fyw != 0 && fyw < fyf) {
+		return fyw
+	}
+	return fyf
+}
+
+// FormFactor returns kf, defaulting to 1 when the table has no value.
+func (sp SteelProperty) FormFactor() float64 {
+	if kf, ok := Number(sp.Kf); ok && kf > 0 {
+		return kf
+	}
+	return 1
+}
+
+// MinorZe returns the minor axis effective modulus Ze in 10³mm³: Zey, or
+// for a channel, whose tables give ZeyL and ZeyR for the web and the flange
+// tips in compression, the lower of the two.
+func (sp SteelProperty) MinorZe() float64 {
+	if sp.Zey > 0 || (sp.ZeyL == 0 && sp.ZeyR == 0) {
+		return sp.Zey
+	}
+	if sp.ZeyL == 0 || (sp.ZeyR != 0 && sp.ZeyR < sp.ZeyL) {
+		return sp.ZeyR
+	}
+	return sp.ZeyL
+}
+
+// WarpingConstant returns Iw in 10⁹mm⁶, or 0 if absent.
+func (sp SteelProperty) WarpingConstant() float64 {
+	f, _ := Number(sp.Iw)
+	return f
+}
+
+// TensileStrength returns Fu in MPa if the table has it.
+func (sp SteelProperty) TensileStrength() (float64, bool) {
+	return Number(sp.Fu)
+}
+
+// CompressionAlphaB returns αb if the table has it.
+func (sp SteelProperty) CompressionAlphaB() (float64, bool) {
+	return Number(sp.AlphaB)
+}
@@ -0,0 +1,175 @@
+// Package query filters and sorts steel property rows by column value.
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"steel_tables/internal/columns"
+	"steel_tables/internal/models"
+)
+
+// Condition is a single comparison such as "Weight<50" or "Residual=HR".
+type Condition struct {
+	Column ColumnRef
+	Op     string
+	Value  string
+}
+
+// ColumnRef resolves a column name, including the Section pseudo-column.
+type ColumnRef struct {
+	Name      string
+	Formatter func(models.SteelProperty) string
+}
+
+// operators are ordered so two-character operators match first.
+var operators = []string{"<=", ">=", "!=", "<", ">", "="}
+
+// Resolve finds a column by name. "Section" refers to the cleaned section name.
+func Resolve(name string) (ColumnRef, error) {
+	if strings.EqualFold(name, "Section") {
+		return ColumnRef{Name: "Section", Formatter: func(p models.SteelProperty) string {
+			return columns.CleanSectionName(p.Section)
+		}}, nil
+	}
+	col, ok := columns.Find(append(columns.GetAll(), columns.GetCapacities()...), name)
+	if !ok {
+		return ColumnRef{}, fmt.Errorf("unknown column '%s'", name)
+	}
+	return ColumnRef{Name: col.Name, Formatter: col.Formatter}, nil
+}
+
+// ParseWhere parses a comma-separated list of conditions, e.g. "d>=300,Weight<60".
+func ParseWhere(expr string) ([]Condition, error) {
+	var conditions []Condition
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		cond, err := parseCondition(part)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, cond)
+	}
+	return conditions, nil
+}
+
+func parseCondition(part string) (Condition, error) {
+	for _, op := range operators {
+		idx := strings.Index(part, op)
+		if idx <= 0 {
+			continue
+		}
+		col, err := Resolve(strings.TrimSpace(part[:idx]))
+		if err != nil {
+			return Condition{}, err
+		}
+		return Condition{
+			Column: col,
+			Op:     op,
+			Value:  strings.TrimSpace(part[idx+len(op):]),
+		}, nil
+	}
+	return Condition{}, fmt.Errorf("invalid condition '%s' (expected e.g. Weight<50)", part)
+}
+
+// Match reports whether a row satisfies the condition. Numeric values are
+// compared numerically; anything else is compared as case-insensitive text.
+func (c Condition) Match(p models.SteelProperty) bool {
+	value := c.Column.Formatter(p)
+	a, aok := columns.NumericValue(value)
+	b, bok := columns.NumericValue(c.Value)
+	var cmp int
+	switch {
+	case aok && bok:
+		cmp = compareFloat(a, b)
+	case aok != bok:
+		// A number never equals text; ordering comparisons fail.
+		return c.Op == "!="
+	default:
+		cmp = strings.Compare(strings.ToLower(value), strings.ToLower(c.Value))
+	}
+
+	switch c.Op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// Filter returns the rows matching every condition.
+func Filter(properties []models.SteelProperty, conditions []Condition) []models.SteelProperty {
+	if len(conditions) == 0 {
+		return properties
+	}
+	var matched []models.SteelProperty
+	for _, p := range properties {
+		ok := true
+		for _, c := range conditions {
+			if !c.Match(p) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+// Sort orders rows in place by the given key. A leading "-" sorts descending.
+// Rows without a value for the column always sort last.
+func Sort(properties []models.SteelProperty, key string) error {
+	descending := strings.HasPrefix(key, "-")
+	col, err := Resolve(strings.TrimPrefix(key, "-"))
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(properties, func(i, j int) bool {
+		vi, vj := col.Formatter(properties[i]), col.Formatter(properties[j])
+		if blank(vi) || blank(vj) {
+			return !blank(vi) && blank(vj)
+		}
+		a, aok := columns.NumericValue(vi)
+		b, bok := columns.NumericValue(vj)
+		var cmp int
+		if aok && bok {
+			cmp = compareFloat(a, b)
+		} else {
+			cmp = strings.Compare(vi, vj)
+		}
+		if descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return nil
+}
+
+func blank(value string) bool {
+	return value == "" || value == "-"
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,263 @@
+// Package selector searches the catalog for the lightest sections that meet
+// a set of design actions.
+package selector
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/calc"
+	"steel_tables/internal/catalog"
+	"steel_tables/internal/models"
+)
+
+// Requirements are the design actions and serviceability limits a section
+// must satisfy. Zero values skip the corresponding check.
+type Requirements struct {
+	M      float64 // Design major axis moment M* (kNm)
+	V      float64 // Design shear force V* (kN)
+	N      float64 // Design axial force N* (kN, compression positive)
+	Le     float64 // Effective length for buckling (m)
+	AlphaM float64 // Moment modification factor αm
+
+	Span            float64 // Simply supported span for deflection (m)
+	ServiceLoad     float64 // Serviceability UDL (kN/m)
+	DeflectionLimit float64 // Span/limit ratio, e.g. 250
+}
+
+// Utilisation is a single design ratio for a candidate.
+type Utilisation struct {
+	Name   string
+	Clause string // Governing AS 4100 clause, when the check has several
+	Ratio  float64
+}
+
+// Label returns the check name with its governing clause, if any.
+func (u Utilisation) Label() string {
+	if u.Clause == "" {
+		return u.Name
+	}
+	return fmt.Sprintf("%s (Cl. %s)", u.Name, u.Clause)
+}
+
+// Candidate is a section that was checked against the requirements.
+type Candidate struct {
+	Table     string
+	Property  models.SteelProperty
+	Checks    []Utilisation
+	Governing Utilisation
+}
+
+// Passes reports whether every check is within capacity.
+func (c Candidate) Passes() bool {
+	return c.Governing.Ratio <= 1
+}
+
+// Validate reports requirements that cannot be checked: an axial force needs
+// an effective length for the member checks.
+func (req Requirements) Validate() error {
+	switch {
+	case req.M == 0 && req.V == 0 && req.N == 0 && (req.Span <= 0 || req.ServiceLoad <= 0 || req.DeflectionLimit <= 0):
+		return fmt.Errorf("no requirements given")
+	case req.N != 0 && req.Le <= 0:
+		return fmt.Errorf("an axial force N* needs an effective length Le for the member checks")
+	}
+	return nil
+}
+
+// Search checks every row in the named tables, keeping rows whose grade is in
+// grades (all grades if empty), and returns the passing candidates ordered by
+// weight, lightest first.
+func Search(tables []string, grades []int, req Requirements) ([]Candidate, error) {
+	var passing []Candidate
+	seen := make(map[string]bool)
+	for _, table := range tables {
+		rows, err := catalog.Load(table)
+		if err != nil {
+			return nil, err
+		}
+		candidates, err := Evaluate(table, filterGrades(rows, grades), req)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range candidates {
+			// Some tables repeat rows from a lower grade table.
+			if c.Passes() && !seen[c.Property.Section] {
+				seen[c.Property.Section] = true
+				passing = append(passing, c)
+			}
+		}
+	}
+	Rank(passing)
+	return passing, nil
+}
+
+// Evaluate checks each row against the requirements. Rows the checks do not
+// cover (e.g. angles in bending) are skipped; any other failure to check a
+// row is returned as an error.
+func Evaluate(table string, rows []models.SteelProperty, req Requirements) ([]Candidate, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var candidates []Candidate
+	for _, p := range rows {
+		if !covers(p, req) {
+			continue
+		}
+		checks, err := check(p, req)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Section, err)
+		}
+		c := Candidate{Table: table, Property: p, Checks: checks}
+		for _, u := range checks {
+			if u.Ratio > c.Governing.Ratio || c.Governing.Name == "" {
+				c.Governing = u
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// covers reports whether the checks for req apply to p's section family:
+// combined actions cover I-sections, channels and hollow sections, and
+// lateral-torsional buckling covers I-sections and channels.
+func covers(p models.SteelProperty, req Requirements) bool {
+	family := p.Family()
+	switch {
+	case family == models.FamilyUnknown:
+		return false
+	case req.N != 0:
+		return family.IsISection() || family.IsChannel() || family.IsHollow()
+	case req.M != 0 && req.Le > 0:
+		return family.IsISection() || family.IsChannel() || family.IsHollow()
+	case req.M != 0:
+		return !family.IsAngle()
+	}
+	return true
+}
+
+// Rank orders candidates by weight, then by governing ratio.
+func Rank(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		wi, wj := candidates[i].Property.Weight, candidates[j].Property.Weight
+		if wi != wj {
+			return wi < wj
+		}
+		return candidates[i].Governing.Ratio > candidates[j].Governing.Ratio
+	})
+}
+
+func check(p models.SteelProperty, req Requirements) ([]Utilisation, error) {
+	var checks []Utilisation
+	alphaM := req.AlphaM
+	if alphaM <= 0 {
+		alphaM = 1
+	}
+
+	if req.N != 0 {
+		res, err := calc.CheckCombined(p, calc.CombinedActions{
+			N: req.N, Mx: req.M, Lex: req.Le, Ley: req.Le, Le: req.Le, AlphaM: alphaM, BetaMx: -1, BetaMy: -1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, Utilisation{"Combined", res.Governing.Clause, res.Governing.Ratio})
+	} else if req.M != 0 {
+		phiMb, err := memberMoment(p, req.Le, alphaM)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, Utilisation{Name: "Moment", Ratio: calc.Ratio(req.M, phiMb)})
+	}
+
+	if req.V != 0 {
+		checks = append(checks, Utilisation{Name: "Shear", Ratio: calc.Ratio(req.V, calc.Section(p).PhiVv)})
+	}
+
+	if req.Span > 0 && req.ServiceLoad > 0 && req.DeflectionLimit > 0 {
+		beam := calc.BeamLoading{Support: calc.SimplySupported, Span: req.Span, UDL: req.ServiceLoad}
+		d, err := calc.BeamDeflection(beam, p.Ix, req.DeflectionLimit)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, Utilisation{Name: "Deflection", Ratio: d.Ratio})
+	}
+
+	return checks, nil
+}
+
+// memberMoment returns φMbx for sections susceptible to lateral-torsional
+// buckling and φMsx for hollow sections.
+func memberMoment(p models.SteelProperty, le, alphaM float64) (float64, error) {
+	family := p.Family()
+	if family.IsHollow() || le <= 0 {
+		return calc.Section(p).PhiMsx, nil
+	}
+	mb, err := calc.MemberMomentCapacity(p, le, alphaM)
+	if err != nil {
+		return 0, err
+	}
+	return mb.PhiMbx, nil
+}
+
+func filterGrades(rows []models.SteelProperty, grades []int) []models.SteelProperty {
+	if len(grades) == 0 {
+		return rows
+	}
+	var kept []models.SteelProperty
+	for _, p := range rows {
+		for _, g := range grades {
+			if p.Grade == g {
+				kept = append(kept, p)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// TablesFor returns the catalog tables belonging to the given families
+// (e.g. "UB", "PFC"), or every table if families is empty.
+func TablesFor(families []string) ([]string, error) {
+	all, err := catalog.Tables()
+	if err != nil {
+		return nil, err
+	}
+	if len(families) == 0 {
+		return all, nil
+	}
+	var tables []string
+	for _, table := range all {
+		prefix := strings.TrimRightFunc(table, func(r rune) bool { return r >= '0' && r <= '9' })
+		for _, f := range families {
+			if strings.EqualFold(prefix, f) {
+				tables = append(tables, table)
+				break
+			}
+		}
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables for families %s", strings.Join(families, ", "))
+	}
+	return tables, nil
+}
+
+// ParseGrades parses a comma-separated list of grades.
+func ParseGrades(list string) ([]int, error) {
+	var grades []int
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		g, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grade '%s'", part)
+		}
+		grades = append(grades, g)
+	}
+	return grades, nil
+}
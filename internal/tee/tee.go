@@ -0,0 +1,214 @@
+// Package tee derives the properties of tee sections cut from I-section
+// table rows at mid-depth.
+//
+// Tees are named after their parent like the published half-beam tables: a
+// 410UB53.7 gives a 205BT26.9, a 310UC158 a 155CT79.0 and a 1200WB455 a
+// 600WT227.5. The flange is at the top; x is the horizontal centroidal axis.
+package tee
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/classify"
+	"steel_tables/internal/models"
+)
+
+// codes maps the parent family to the tee family code.
+var codes = map[models.Family]models.Family{
+	models.FamilyUB: models.FamilyBT,
+	models.FamilyUC: models.FamilyCT,
+	models.FamilyWB: models.FamilyWT,
+	models.FamilyWC: models.FamilyWT,
+}
+
+// CanCut reports whether tees can be cut from a row.
+func CanCut(p models.SteelProperty) bool {
+	_, ok := codes[p.Family()]
+	return ok && p.Bf2 == 0
+}
+
+// Table returns the tees cut from every row that CanCut.
+func Table(parents []models.SteelProperty) []models.SteelProperty {
+	var tees []models.SteelProperty
+	for _, p := range parents {
+		if t, err := Cut(p); err == nil {
+			tees = append(tees, t)
+		}
+	}
+	return tees
+}
+
+// Name returns the tee designation for a parent I-section, halving the
+// nominal depth and mass in its name.
+func Name(parent models.SteelProperty) (string, error) {
+	code, ok := codes[parent.Family()]
+	if !ok {
+		return "", fmt.Errorf("tees are cut from UB, UC, WB and WC sections, not %s", parent.Section)
+	}
+	name, suffix := models.SplitGrade(parent.Section)
+	parts := strings.SplitN(strings.ReplaceAll(name, " ", ""), string(parent.Family()), 2)
+	depth, errD := strconv.Atoi(parts[0])
+	if len(parts) != 2 || errD != nil {
+		return "", fmt.Errorf("cannot name a tee cut from %s", parent.Section)
+	}
+	mass, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return "", fmt.Errorf("cannot name a tee cut from %s", parent.Section)
+	}
+	// Round the half mass in tenths of a kg, halves up.
+	tenths := (int(math.Round(mass*10)) + 1) / 2
+	return fmt.Sprintf("%d%s%d.%d%s", (depth+1)/2, code, tenths/10, tenths%10, suffix), nil
+}
+
+// strips is the number of horizontal strips the fillet zone is divided into
+// when integrating the section.
+const strips = 200
+
+// Cut returns the properties of the tee cut from an I-section row. Area,
+// centroid, Ix and Sx include the root fillets; Iy, Sy, Zey and J are half
+// the parent's and Iw is the approximation (bf³tf³/144 + h³tw³/36) with h
+// the stem depth to the flange centre.
+func Cut(parent models.SteelProperty) (models.SteelProperty, error) {
+	if !CanCut(parent) {
+		return models.SteelProperty{}, fmt.Errorf("tees are cut from UB, UC, WB and WC sections, not %s", parent.Section)
+	}
+	name, err := Name(parent)
+	if err != nil {
+		return models.SteelProperty{}, err
+	}
+	t, err := CutAt(parent, parent.D/2)
+	if err != nil {
+		return models.SteelProperty{}, err
+	}
+	t.Section = name
+	return t, nil
+}
+
+// CutAt returns the properties of a tee of overall depth d (mm) cut from an
+// I-section row, as at the openings of a castellated or cellular beam. The
+// minor axis properties and J are half the parent's less the web removed
+// below the cut. The section keeps the parent's designation.
+func CutAt(parent models.SteelProperty, d float64) (models.SteelProperty, error) {
+	if !CanCut(parent) {
+		return models.SteelProperty{}, fmt.Errorf("tees are cut from UB, UC, WB and WC sections, not %s", parent.Section)
+	}
+	if d <= parent.Tf || d > parent.D/2 {
+		return models.SteelProperty{}, fmt.Errorf("a tee cut from %s must be deeper than the %.1f mm flange and no deeper than %.1f mm", parent.Section, parent.Tf, parent.D/2)
+	}
+	bf, tf, tw := parent.Bf, parent.Tf, parent.Tw
+	r, _ := models.Number(parent.R1)
+	r = math.Min(r, d-tf)
+
+	// Horizontal strips from the top of the flange: y is the depth of the
+	// strip centre, w its width and h its height.
+	type strip struct{ y, w, h float64 }
+	s := []strip{{tf / 2, bf, tf}}
+	for i := 0; i < strips && r > 0; i++ {
+		h := r / strips
+		y := tf + (float64(i)+0.5)*h
+		below := tf + r - y // Height of the strip below the flange side of the fillet centre
+		s = append(s, strip{y, tw + 2*(r-math.Sqrt(r*r-below*below)), h})
+	}
+	if stem := d - tf - r; stem > 0 {
+		s = append(s, strip{tf + r + stem/2, tw, stem})
+	}
+
+	area, moment := 0.0, 0.0
+	for _, st := range s {
+		area += st.w * st.h
+		moment += st.w * st.h * st.y
+	}
+	yc := moment / area
+	ix := 0.0
+	for _, st := range s {
+		ix += st.w*math.Pow(st.h, 3)/12 + st.w*st.h*math.Pow(st.y-yc, 2)
+	}
+
+	// Plastic neutral axis at half the area, then the first moments of
+	// area either side of it.
+	yp, above := 0.0, 0.0
+	for _, st := range s {
+		a := st.w * st.h
+		if above+a >= area/2 {
+			yp = st.y - st.h/2 + (area/2-above)/st.w
+			break
+		}
+		above += a
+	}
+	sx := 0.0
+	for _, st := range s {
+		top, bottom := st.y-st.h/2, st.y+st.h/2
+		for _, part := range [][2]float64{{top, math.Min(bottom, yp)}, {math.Max(top, yp), bottom}} {
+			if part[1] > part[0] {
+				sx += st.w * (part[1] - part[0]) * math.Abs((part[0]+part[1])/2-yp)
+			}
+		}
+	}
+
+	zxTop, zxBottom := ix/yc, ix/(d-yc)
+	zx := math.Min(zxTop, zxBottom)
+	// Web removed between the cut and mid-depth of the parent.
+	removed := parent.D/2 - d
+	iy := parent.Iy/2 - removed*math.Pow(tw, 3)/12/1e6
+	sy := parent.Sy/2 - removed*tw*tw/4/1e3
+
+	// Classification about x: the flange outstand in uniform compression
+	// (flange in compression) or the stem with its tip in compression.
+	residual := classify.Residual(parent)
+	uniform, gradient := classify.OutstandUniform[residual], classify.OutstandGradient[residual]
+	fyf, fyw := parent.FlangeYield(), parent.WebYield()
+	if fyw == 0 {
+		fyw = fyf
+	}
+	flange := classify.Flat("flange outstand", (bf-tw)/2, tf, fyf, uniform)
+	x := classify.Bending(zx, sx, flange, classify.Flat("stem", d-tf, tw, fyw, gradient))
+
+	// kf: the stem is an outstand like the flange in uniform compression.
+	stem := classify.Flat("stem", d-tf, tw, fyw, uniform)
+	ineffective := 2*classify.Ineffective(flange.Width, tf, flange.Lambda, flange.Yield) +
+		classify.Ineffective(stem.Width, tw, stem.Lambda, stem.Yield)
+
+	h := d - tf/2
+	iw := (math.Pow(bf, 3)*math.Pow(tf, 3)/144 + math.Pow(h, 3)*math.Pow(tw, 3)/36) / 1e9
+
+	return models.SteelProperty{
+		Section:  parent.Section,
+		Grade:    parent.Grade,
+		Weight:   models.Round(parent.Weight/2-removed*tw*models.SteelDensity, 2),
+		D:        d,
+		Bf:       bf,
+		Tf:       tf,
+		Tw:       tw,
+		R1:       parent.R1,
+		D1:       models.Round(d-tf, 1),
+		Tw1:      models.Round((d-tf)/tw, 1),
+		TwoTf:    parent.TwoTf,
+		Ag:       models.Round(area, 0),
+		Ix:       models.Round(ix/1e6, 2),
+		Zx:       models.Round(zx/1e3, 1),
+		ZxT:      models.Round(zxTop/1e3, 1),
+		ZxB:      models.Round(zxBottom/1e3, 1),
+		Sx:       models.Round(sx/1e3, 1),
+		Rx:       models.Round(math.Sqrt(ix/area), 1),
+		Iy:       models.Round(iy, 2),
+		Zy:       models.Round(parent.Zy/2*iy/(parent.Iy/2), 1),
+		Sy:       models.Round(sy, 1),
+		Ry:       models.Round(math.Sqrt(iy*1e6/area), 1),
+		J:        models.Round(parent.J/2-removed*math.Pow(tw, 3)/3/1e3, 0),
+		Iw:       models.Round(iw, 3),
+		Flange:   parent.Flange,
+		Web:      parent.Web,
+		Kf:       models.Round(1-ineffective/area, 3),
+		CNS:      string(x.Class),
+		Zex:      models.Round(x.Ze/1e3, 1),
+		CNS2:     parent.CNS2,
+		Zey:      models.Round(parent.Zey/2*sy/(parent.Sy/2), 1),
+		Fu:       parent.Fu,
+		PT:       models.Round(yc, 1),
+		PB:       models.Round(d-yc, 1),
+		Residual: parent.Residual,
+	}, nil
+}
@@ -3,13 +3,19 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // DrawHeader draws the title box with table name and page info.
-func DrawHeader(filename string, currentPage, totalPages, totalEntries int) {
-	termWidth := GetTerminalWidth()
+func (r *Renderer) DrawHeader(filename string, currentPage, totalPages, totalEntries int) {
 	titleText := fmt.Sprintf("STEEL PROPERTIES: %s", strings.ToUpper(strings.TrimSuffix(filename, ".json")))
 	infoText := fmt.Sprintf("Page %d/%d | %d entries", currentPage, totalPages, totalEntries)
+	r.DrawTitleBox(titleText, infoText)
+}
+
+// DrawTitleBox draws a centred double-line box with a title and info line.
+func (r *Renderer) DrawTitleBox(titleText, infoText string) {
+	termWidth := r.Width
 	infoText = strings.TrimSpace(infoText)
 
 	boxWidth := len(titleText)
@@ -33,63 +39,79 @@ func DrawHeader(filename string, currentPage, totalPages, totalEntries int) {
 		remainingSpace = 0
 	}
 
+	bg, reset, border := r.C(Bg), r.C(Reset), r.C(BorderBright)
+
 	// Top border
-	fmt.Printf("%s%s", Bg, strings.Repeat(" ", centerOffset))
-	fmt.Printf("%s╔%s╗", BorderBright, strings.Repeat("═", boxWidth))
-	fmt.Printf("%s%s%s\n", Bg, strings.Repeat(" ", remainingSpace), Reset)
+	r.Printf("%s%s", bg, strings.Repeat(" ", centerOffset))
+	r.Printf("%s╔%s╗", border, strings.Repeat("═", boxWidth))
+	r.Printf("%s%s%s\n", bg, strings.Repeat(" ", remainingSpace), reset)
 
 	// Title row
 	titlePadding := (boxWidth - len(titleText)) / 2
 	titleRightPadding := boxWidth - len(titleText) - titlePadding
-	fmt.Printf("%s%s", Bg, strings.Repeat(" ", centerOffset))
-	fmt.Printf("%s║%s%s%s%s%s%s%s║",
-		BorderBright, Bg, strings.Repeat(" ", titlePadding), Accent, titleText, Bg, strings.Repeat(" ", titleRightPadding), BorderBright)
-	fmt.Printf("%s%s%s\n", Bg, strings.Repeat(" ", remainingSpace), Reset)
+	r.Printf("%s%s", bg, strings.Repeat(" ", centerOffset))
+	r.Printf("%s║%s%s%s%s%s%s%s║",
+		border, bg, strings.Repeat(" ", titlePadding), r.C(Accent), titleText, bg, strings.Repeat(" ", titleRightPadding), border)
+	r.Printf("%s%s%s\n", bg, strings.Repeat(" ", remainingSpace), reset)
 
 	// Info row
 	infoPadding := (boxWidth - len(infoText)) / 2
 	infoRightPadding := boxWidth - len(infoText) - infoPadding
-	fmt.Printf("%s%s", Bg, strings.Repeat(" ", centerOffset))
-	fmt.Printf("%s║%s%s%s%s%s%s%s║",
-		BorderBright, Bg, strings.Repeat(" ", infoPadding), TextDim, infoText, Bg, strings.Repeat(" ", infoRightPadding), BorderBright)
-	fmt.Printf("%s%s%s\n", Bg, strings.Repeat(" ", remainingSpace), Reset)
+	r.Printf("%s%s", bg, strings.Repeat(" ", centerOffset))
+	r.Printf("%s║%s%s%s%s%s%s%s║",
+		border, bg, strings.Repeat(" ", infoPadding), r.C(TextDim), infoText, bg, strings.Repeat(" ", infoRightPadding), border)
+	r.Printf("%s%s%s\n", bg, strings.Repeat(" ", remainingSpace), reset)
 
 	// Bottom border
-	fmt.Printf("%s%s", Bg, strings.Repeat(" ", centerOffset))
-	fmt.Printf("%s╚%s╝", BorderBright, strings.Repeat("═", boxWidth))
-	fmt.Printf("%s%s%s\n\n", Bg, strings.Repeat(" ", remainingSpace), Reset)
+	r.Printf("%s%s", bg, strings.Repeat(" ", centerOffset))
+	r.Printf("%s╚%s╝", border, strings.Repeat("═", boxWidth))
+	r.Printf("%s%s%s\n\n", bg, strings.Repeat(" ", remainingSpace), reset)
 }
 
 // DrawNavigationFooter draws the row info and keyboard shortcuts.
-func DrawNavigationFooter(currentPage, totalPages, startRow, endRow, totalRows int) {
-	termWidth := GetTerminalWidth()
+func (r *Renderer) DrawNavigationFooter(currentPage, totalPages, startRow, endRow, totalRows int) {
+	r.DrawNavigationFooterStatus(currentPage, totalPages, startRow, endRow, totalRows, "")
+}
+
+// DrawNavigationFooterStatus draws the navigation footer with a status
+// message, if not empty, after the row info.
+func (r *Renderer) DrawNavigationFooterStatus(currentPage, totalPages, startRow, endRow, totalRows int, status string) {
+	termWidth := r.Width
+	bg, reset := r.C(Bg), r.C(Reset)
+	accent, text, dim := r.C(Accent), r.C(Text), r.C(TextDim)
 
 	// Row info line
 	rowInfo := fmt.Sprintf("Rows %d–%d of %d", startRow+1, endRow, totalRows)
 	rowInfoColored := fmt.Sprintf("%sRows %s%d–%d%s of %s%d%s",
-		TextDim, Accent, startRow+1, endRow, TextDim, Accent, totalRows, TextDim)
-	rowPadding := (termWidth - len(rowInfo)) / 2
+		dim, accent, startRow+1, endRow, dim, accent, totalRows, dim)
+	if status != "" {
+		rowInfo += "  |  " + status
+		rowInfoColored += fmt.Sprintf("  |  %s%s%s", r.C(Success), status, dim)
+	}
+	rowInfoWidth := utf8.RuneCountInString(rowInfo)
+	rowPadding := (termWidth - rowInfoWidth) / 2
 	if rowPadding < 0 {
 		rowPadding = 0
 	}
-	rowRightPad := termWidth - len(rowInfo) - rowPadding
+	rowRightPad := termWidth - rowInfoWidth - rowPadding
 	if rowRightPad < 0 {
 		rowRightPad = 0
 	}
-	fmt.Printf("%s%s%s%s%s\n",
-		Bg, strings.Repeat(" ", rowPadding), rowInfoColored, strings.Repeat(" ", rowRightPad), Reset)
+	r.Printf("%s%s%s%s%s\n",
+		bg, strings.Repeat(" ", rowPadding), rowInfoColored, strings.Repeat(" ", rowRightPad), reset)
 
 	// Keyboard shortcuts
-	footerText := fmt.Sprintf("  %s←%s %s→%s pages  |  %s↑%s %s↓%s scroll  |  %sPgUp/PgDn%s jump  |  %sm%s menu  |  %sq%s quit  ",
-		Accent, Text, Accent, Text, Accent, Text, Accent, Text, Accent, Text, Accent, Text, Error, Text)
-	plainText := "  ← → pages  |  ↑ ↓ scroll  |  PgUp/PgDn jump  |  m menu  |  q quit  "
-	padding := (termWidth - len(plainText)) / 2
+	footerText := fmt.Sprintf(" %s←%s %s→%s pages | %s↑%s %s↓%s scroll | %sPgUp/PgDn%s jump | %si%s detail | %ss%s select | %sd%s deflect | %sm%s menu | %sq%s quit ",
+		accent, text, accent, text, accent, text, accent, text, accent, text, accent, text, accent, text, accent, text, accent, text, r.C(Error), text)
+	plainText := " ← → pages | ↑ ↓ scroll | PgUp/PgDn jump | i detail | s select | d deflect | m menu | q quit "
+	plainWidth := utf8.RuneCountInString(plainText)
+	padding := (termWidth - plainWidth) / 2
 	if padding < 0 {
 		padding = 0
 	}
-	rightPad := termWidth - len(plainText) - padding
+	rightPad := termWidth - plainWidth - padding
 	if rightPad < 0 {
 		rightPad = 0
 	}
-	fmt.Printf("%s%s%s%s%s\n", Bg, strings.Repeat(" ", padding), footerText, strings.Repeat(" ", rightPad), Reset)
+	r.Printf("%s%s%s%s%s\n", bg, strings.Repeat(" ", padding), footerText, strings.Repeat(" ", rightPad), reset)
 }
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"os"
+)
+
+// Prompt reads a line of input in raw mode, echoing keystrokes itself.
+// Enter accepts the text, Esc cancels. Returns false if cancelled.
+func (r *Renderer) Prompt(label, initial string) (string, bool) {
+	text := []byte(initial)
+	r.Printf("%s%s%s%s%s", r.C(Bg), r.C(Accent), label, r.C(TextBright), initial)
+
+	buffer := make([]byte, 64)
+	for {
+		n, err := os.Stdin.Read(buffer)
+		if err != nil {
+			return "", false
+		}
+		input := buffer[:n]
+		if input[0] == 27 && n > 1 {
+			// Ignore arrow keys and other escape sequences.
+			continue
+		}
+		for _, b := range input {
+			switch {
+			case b == '\r' || b == '\n':
+				r.Printf("%s\n", r.C(Reset))
+				return string(text), true
+			case b == 27 || b == 3:
+				r.Printf("%s\n", r.C(Reset))
+				return "", false
+			case b == 127 || b == 8:
+				if len(text) > 0 {
+					text = text[:len(text)-1]
+					r.Print("\b \b")
+				}
+			case b >= 32 && b < 127:
+				text = append(text, b)
+				r.Printf("%c", b)
+			}
+		}
+	}
+}
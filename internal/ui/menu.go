@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"steel_tables/internal/catalog"
 	"steel_tables/internal/config"
 )
 
@@ -55,6 +56,11 @@ func PrintWelcomeScreen() string {
 		// Available tables
 		printFullWidthLine("▶ AVAILABLE STEEL TABLES:", Accent, termWidth)
 		listJSONFiles(termWidth)
+		if tees, err := catalog.TeeTables(); err == nil && len(tees) > 0 {
+			fmt.Println()
+			printFullWidthLine("▶ TEES CUT FROM I-SECTIONS:", Accent, termWidth)
+			printFullWidthLine("  "+strings.Join(tees, "  "), Text, termWidth)
+		}
 
 		// Instructions
 		fmt.Println()
@@ -79,7 +85,7 @@ func PrintWelcomeScreen() string {
 		if input == "" {
 			continue
 		}
-		if isValidTable(input) {
+		if catalog.Exists(input) {
 			return input + "_PROPS.json"
 		}
 
@@ -125,17 +131,3 @@ func listJSONFiles(termWidth int) {
 		}
 	}
 }
-
-func isValidTable(tableName string) bool {
-	files, err := os.ReadDir(config.DataDir())
-	if err != nil {
-		return false
-	}
-	expectedFilename := strings.ToUpper(tableName) + "_PROPS.json"
-	for _, file := range files {
-		if file.Name() == expectedFilename {
-			return true
-		}
-	}
-	return false
-}
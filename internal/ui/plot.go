@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// plotLabelWidth is the width of the value labels left of a plot.
+const plotLabelWidth = 9
+
+// Plot draws an envelope diagram as text lines. xs are positions in
+// ascending order with the lower and upper values at each; the band between
+// zero and each bound is filled. Each line starts with a value label.
+func Plot(xs, lower, upper []float64, width, height int) []string {
+	if width < 10 {
+		width = 10
+	}
+	if height < 3 {
+		height = 3
+	}
+	if len(xs) == 0 {
+		return nil
+	}
+
+	// Reduce the stations to one column each.
+	x0, x1 := xs[0], xs[len(xs)-1]
+	lo := make([]float64, width)
+	hi := make([]float64, width)
+	seen := make([]bool, width)
+	for i, x := range xs {
+		c := 0
+		if x1 > x0 {
+			c = int((x - x0) / (x1 - x0) * float64(width))
+		}
+		c = min(max(c, 0), width-1)
+		if !seen[c] {
+			lo[c], hi[c], seen[c] = lower[i], upper[i], true
+			continue
+		}
+		lo[c] = math.Min(lo[c], lower[i])
+		hi[c] = math.Max(hi[c], upper[i])
+	}
+	for c := 1; c < width; c++ {
+		if !seen[c] {
+			lo[c], hi[c] = lo[c-1], hi[c-1]
+		}
+	}
+
+	top, bottom := 0.0, 0.0
+	for c := range lo {
+		top = math.Max(top, hi[c])
+		bottom = math.Min(bottom, lo[c])
+	}
+	if top == bottom {
+		top = 1
+	}
+	step := (top - bottom) / float64(height)
+	zeroRow := min(int(top/step), height-1)
+
+	lines := make([]string, height)
+	for row := 0; row < height; row++ {
+		rowTop := top - float64(row)*step
+		rowBottom := rowTop - step
+		var b strings.Builder
+		switch {
+		case row == 0:
+			fmt.Fprintf(&b, "%*.1f ", plotLabelWidth-1, top)
+		case row == height-1 && bottom < 0:
+			fmt.Fprintf(&b, "%*.1f ", plotLabelWidth-1, bottom)
+		case row == zeroRow:
+			fmt.Fprintf(&b, "%*s ", plotLabelWidth-1, "0")
+		default:
+			b.WriteString(strings.Repeat(" ", plotLabelWidth))
+		}
+		for c := 0; c < width; c++ {
+			filled := (hi[c] > 0 && rowBottom < hi[c] && rowTop > 0) ||
+				(lo[c] < 0 && rowTop > lo[c] && rowBottom < 0)
+			switch {
+			case filled:
+				b.WriteString("█")
+			case row == zeroRow:
+				b.WriteString("─")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		lines[row] = b.String()
+	}
+	return lines
+}
+
+// PlotAxis returns a line of width characters, offset to line up with Plot,
+// with a marker at each of the given positions between x0 and x1.
+func PlotAxis(x0, x1 float64, width int, positions []float64, markers []string) string {
+	cells := make([]string, width)
+	for i := range cells {
+		cells[i] = " "
+	}
+	for i, x := range positions {
+		c := 0
+		if x1 > x0 {
+			c = int((x - x0) / (x1 - x0) * float64(width))
+		}
+		cells[min(max(c, 0), width-1)] = markers[i]
+	}
+	return strings.Repeat(" ", plotLabelWidth) + strings.Join(cells, "")
+}
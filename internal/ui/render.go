@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Column widths used by the table layout.
+const (
+	sectionColWidth = 25
+	dataColWidth    = 18
+)
+
+// Renderer draws UI components to a writer with an explicit size and colour
+// capability, so the same drawing code can target the terminal, a file or a
+// buffer.
+type Renderer struct {
+	W      io.Writer
+	Width  int
+	Height int
+	Color  bool
+}
+
+// NewRenderer creates a renderer for w with the given size in cells.
+func NewRenderer(w io.Writer, width, height int, color bool) *Renderer {
+	return &Renderer{W: w, Width: width, Height: height, Color: color}
+}
+
+// TerminalRenderer creates a renderer for stdout sized to the current terminal.
+func TerminalRenderer(color bool) *Renderer {
+	return NewRenderer(os.Stdout, GetTerminalWidth(), GetTerminalHeight(), color)
+}
+
+// MaxCols computes how many data columns fit in the renderer width.
+func (r *Renderer) MaxCols() int {
+	maxCols := (r.Width - sectionColWidth) / dataColWidth
+	if maxCols < 1 {
+		maxCols = 1
+	}
+	return maxCols
+}
+
+// C returns the escape code when colour is enabled, or "" otherwise.
+func (r *Renderer) C(code string) string {
+	if r.Color {
+		return code
+	}
+	return ""
+}
+
+// Printf writes formatted output to the renderer's writer.
+func (r *Renderer) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(r.W, format, args...)
+}
+
+// Print writes its arguments to the renderer's writer.
+func (r *Renderer) Print(args ...interface{}) {
+	fmt.Fprint(r.W, args...)
+}
+
+// Println writes its arguments and a newline to the renderer's writer.
+func (r *Renderer) Println(args ...interface{}) {
+	fmt.Fprintln(r.W, args...)
+}
+
+// BlankLines writes n empty background-coloured lines.
+func (r *Renderer) BlankLines(n int) {
+	for i := 0; i < n; i++ {
+		r.Printf("%s%s%s\n", r.C(Bg), strings.Repeat(" ", r.Width), r.C(Reset))
+	}
+}
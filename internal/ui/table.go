@@ -1,85 +1,181 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"steel_tables/internal/columns"
 	"steel_tables/internal/models"
 )
 
 // DrawColumnHeaders draws the column header row with units.
-func DrawColumnHeaders(currentColumns []columns.ColumnInfo) {
-	termWidth := GetTerminalWidth()
-	fmt.Printf("%s%s", BgLight, Accent)
-	fmt.Printf("%-25s", "Section")
+func (r *Renderer) DrawColumnHeaders(currentColumns []columns.ColumnInfo) {
+	r.Printf("%s%s", r.C(BgLight), r.C(Accent))
+	r.Printf("%-*s", sectionColWidth, "Section")
 	for _, col := range currentColumns {
 		headerText := columns.GetHeaderWithUnit(col.Name)
-		fmt.Printf("%-18s", truncateString(headerText, 17))
+		r.Printf("%-*s", dataColWidth, truncateString(headerText, dataColWidth-1))
 	}
-	usedSpace := 25 + (len(currentColumns) * 18)
-	remainingSpace := termWidth - usedSpace
+	usedSpace := sectionColWidth + (len(currentColumns) * dataColWidth)
+	remainingSpace := r.Width - usedSpace
 	if remainingSpace > 0 {
-		fmt.Printf("%s", strings.Repeat(" ", remainingSpace))
+		r.Print(strings.Repeat(" ", remainingSpace))
 	}
-	fmt.Printf("%s\n", Reset)
+	r.Printf("%s\n", r.C(Reset))
 
 	// Separator line
-	fmt.Printf("%s%s", BgLight, BorderBright)
-	fmt.Print(strings.Repeat("─", 25))
+	r.Printf("%s%s", r.C(BgLight), r.C(BorderBright))
+	r.Print(strings.Repeat("─", sectionColWidth))
 	for range currentColumns {
-		fmt.Print(strings.Repeat("─", 18))
+		r.Print(strings.Repeat("─", dataColWidth))
 	}
 	if remainingSpace > 0 {
-		fmt.Print(strings.Repeat("─", remainingSpace))
+		r.Print(strings.Repeat("─", remainingSpace))
 	}
-	fmt.Printf("%s\n", Reset)
+	r.Printf("%s\n", r.C(Reset))
 }
 
 // DrawDataRows draws property rows starting at index 0.
-func DrawDataRows(properties []models.SteelProperty, currentColumns []columns.ColumnInfo) {
-	DrawDataRowsOffset(properties, currentColumns, 0)
+func (r *Renderer) DrawDataRows(properties []models.SteelProperty, currentColumns []columns.ColumnInfo) {
+	r.DrawDataRowsOffset(properties, currentColumns, 0)
 }
 
 // DrawDataRowsOffset draws property rows with a base offset for alternating colors.
-func DrawDataRowsOffset(properties []models.SteelProperty, currentColumns []columns.ColumnInfo, baseIndex int) {
-	termWidth := GetTerminalWidth()
+func (r *Renderer) DrawDataRowsOffset(properties []models.SteelProperty, currentColumns []columns.ColumnInfo, baseIndex int) {
+	r.DrawDataRowsHighlight(properties, currentColumns, baseIndex, nil)
+}
+
+// DrawDataRowsHighlight draws property rows like DrawDataRowsOffset. highlight,
+// if not nil, marks rows whose section name is drawn in the success colour.
+func (r *Renderer) DrawDataRowsHighlight(properties []models.SteelProperty, currentColumns []columns.ColumnInfo, baseIndex int, highlight func(models.SteelProperty) bool) {
 	for i, prop := range properties {
 		globalIndex := baseIndex + i
 		if globalIndex%2 == 0 {
-			fmt.Printf("%s", Bg)
+			r.Print(r.C(Bg))
 		} else {
-			fmt.Printf("%s", BgLight)
+			r.Print(r.C(BgLight))
 		}
 
-		cleanedSection := cleanSectionName(prop.Section)
-		fmt.Printf("%s%-25s%s", TextBright, truncateString(cleanedSection, 24), Text)
+		sectionColor := r.C(TextBright)
+		if highlight != nil && highlight(prop) {
+			sectionColor = r.C(Success)
+		}
+		cleanedSection := columns.CleanSectionName(prop.Section)
+		r.Printf("%s%-*s%s", sectionColor, sectionColWidth, truncateString(cleanedSection, sectionColWidth-1), r.C(Text))
 
 		for _, col := range currentColumns {
 			value := col.Formatter(prop)
 			if value == "-" || value == "" {
-				fmt.Printf("%s%-18s", TextDim, truncateString(value, 17))
+				r.Printf("%s%-*s", r.C(TextDim), dataColWidth, truncateString(value, dataColWidth-1))
 			} else {
-				fmt.Printf("%s%-18s", Text, truncateString(value, 17))
+				r.Printf("%s%-*s", r.C(Text), dataColWidth, truncateString(value, dataColWidth-1))
 			}
 		}
 
-		usedSpace := 25 + (len(currentColumns) * 18)
-		remainingSpace := termWidth - usedSpace
+		usedSpace := sectionColWidth + (len(currentColumns) * dataColWidth)
+		remainingSpace := r.Width - usedSpace
 		if remainingSpace > 0 {
-			fmt.Printf("%s", strings.Repeat(" ", remainingSpace))
+			r.Print(strings.Repeat(" ", remainingSpace))
+		}
+		r.Printf("%s\n", r.C(Reset))
+	}
+}
+
+// DrawTextTable draws pre-formatted rows under a header row, with columns
+// sized to their content and alternating row backgrounds. highlight, if not
+// nil, marks rows to draw in the accent colour.
+func (r *Renderer) DrawTextTable(headers []string, rows [][]string, highlight func(row int) bool) {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			if n := utf8.RuneCountInString(value); i < len(widths) && n > widths[i] {
+				widths[i] = n
+			}
 		}
-		fmt.Printf("%s\n", Reset)
 	}
+
+	r.drawTextRow(headers, widths, r.C(BgLight), r.C(Accent))
+	for i, row := range rows {
+		bg := r.C(Bg)
+		if i%2 == 1 {
+			bg = r.C(BgLight)
+		}
+		fg := r.C(Text)
+		if highlight != nil && highlight(i) {
+			fg = r.C(Success)
+		}
+		r.drawTextRow(row, widths, bg, fg)
+	}
+}
+
+func (r *Renderer) drawTextRow(values []string, widths []int, bg, fg string) {
+	var sb strings.Builder
+	sb.WriteString("  ")
+	for i, value := range values {
+		if i >= len(widths) {
+			break
+		}
+		sb.WriteString(value)
+		sb.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(value)+2))
+	}
+	line := sb.String()
+	padding := r.Width - utf8.RuneCountInString(line)
+	if padding < 0 {
+		padding = 0
+	}
+	r.Printf("%s%s%s%s%s\n", bg, fg, line, strings.Repeat(" ", padding), r.C(Reset))
 }
 
-func cleanSectionName(section string) string {
-	if idx := strings.Index(section, " (G"); idx != -1 {
-		if endIdx := strings.Index(section[idx:], ")"); endIdx != -1 {
-			return section[:idx] + section[idx+endIdx+1:]
+// DrawPlainTable draws properties as aligned plain text without colour codes
+// or width padding, suitable for redirecting to a file or pipe.
+func (r *Renderer) DrawPlainTable(properties []models.SteelProperty, currentColumns []columns.ColumnInfo) {
+	headers := []string{"Section"}
+	for _, col := range currentColumns {
+		headers = append(headers, columns.GetHeaderWithUnit(col.Name))
+	}
+
+	rows := make([][]string, len(properties))
+	for i, prop := range properties {
+		row := []string{columns.CleanSectionName(prop.Section)}
+		for _, col := range currentColumns {
+			row = append(row, col.Formatter(prop))
+		}
+		rows[i] = row
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			if n := utf8.RuneCountInString(value); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	r.drawPlainRow(headers, widths)
+	for _, row := range rows {
+		r.drawPlainRow(row, widths)
+	}
+}
+
+func (r *Renderer) drawPlainRow(values []string, widths []int) {
+	var sb strings.Builder
+	for i, value := range values {
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(value)
+		if i < len(values)-1 {
+			sb.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(value)))
 		}
 	}
-	return section
+	r.Println(sb.String())
 }
 
 func truncateString(s string, maxLen int) string {
@@ -86,15 +86,3 @@ func GetTerminalHeight() int {
 	}
 	return height
 }
-
-// GetMaxCols computes how many data columns fit in the terminal width.
-func GetMaxCols() int {
-	termWidth := GetTerminalWidth()
-	sectionColWidth := 25
-	colWidth := 18
-	maxCols := (termWidth - sectionColWidth) / colWidth
-	if maxCols < 1 {
-		maxCols = 1
-	}
-	return maxCols
-}
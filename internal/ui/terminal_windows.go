@@ -28,8 +28,3 @@ func GetTerminalWidth() int {
 func GetTerminalHeight() int {
 	return 40
 }
-
-// GetMaxCols computes column count based on default width.
-func GetMaxCols() int {
-	return 5
-}
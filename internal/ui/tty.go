@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+)
+
+// ColorMode controls when colour and screen control codes are written.
+type ColorMode int
+
+const (
+	// ColorAuto enables colour only when writing to a terminal.
+	ColorAuto ColorMode = iota
+	// ColorAlways enables colour regardless of the output device.
+	ColorAlways
+	// ColorNever disables colour regardless of the output device.
+	ColorNever
+)
+
+// ParseColorMode parses a --color flag value.
+func ParseColorMode(value string) (ColorMode, error) {
+	switch value {
+	case "", "auto":
+		return ColorAuto, nil
+	case "always":
+		return ColorAlways, nil
+	case "never":
+		return ColorNever, nil
+	}
+	return ColorAuto, fmt.Errorf("invalid color mode %q (want auto, always or never)", value)
+}
+
+// Enabled reports whether colour should be used when writing to f.
+func (m ColorMode) Enabled(f *os.File) bool {
+	switch m {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	return IsTerminal(f)
+}
+
+// IsTerminal reports whether f is attached to a terminal.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,97 @@
+package viewer
+
+import (
+	"os"
+	"strconv"
+
+	"steel_tables/internal/compound"
+	"steel_tables/internal/models"
+	"steel_tables/internal/ui"
+)
+
+// lastCompound remembers the arrangement and gap between uses of the panel.
+var lastCompound = map[bool]compound.Spec{
+	true:  {Arrangement: compound.LongLegsBackToBack},
+	false: {Arrangement: compound.BackToBack},
+}
+
+// canCompound reports whether double sections can be built from the rows of
+// a table: catalog angles or channels, not rows that are already compound.
+func canCompound(properties []models.SteelProperty) bool {
+	if len(properties) == 0 || compound.IsCompound(properties[0].Section) {
+		return false
+	}
+	family := properties[0].Family()
+	return family.IsAngle() || family.IsChannel()
+}
+
+// runCompoundPanel prompts for an arrangement and gap and returns the
+// compound rows built from properties and a title for them. It returns false
+// if the user cancels.
+func runCompoundPanel(tableName string, properties []models.SteelProperty) ([]models.SteelProperty, string, bool) {
+	angles := properties[0].Family().IsAngle()
+	spec := lastCompound[angles]
+	options := "BTB, BOX"
+	if angles {
+		options = "LLBB, SLBB"
+	}
+
+	r := ui.TerminalRenderer(true)
+	r.Print(ui.Bg + ui.Clear)
+	r.DrawTitleBox("DOUBLE SECTIONS: "+tableName, "Enter accepts, Esc cancels")
+
+	for {
+		text, ok := r.Prompt("  Arrangement ("+options+") : ", string(spec.Arrangement))
+		if !ok {
+			return nil, "", false
+		}
+		a, err := compound.ParseArrangement(text)
+		if err == nil && (a == compound.LongLegsBackToBack || a == compound.ShortLegsBackToBack) == angles {
+			spec.Arrangement = a
+			break
+		}
+		r.Printf("%s%s  ✗ want %s%s\n", ui.Bg, ui.Error, options, ui.Reset)
+	}
+	for {
+		text, ok := r.Prompt("  Gap (mm)                : ", formatInput(spec.Gap))
+		if !ok {
+			return nil, "", false
+		}
+		if text == "" {
+			spec.Gap = 0
+			break
+		}
+		v, err := strconv.ParseFloat(text, 64)
+		if err == nil && v >= 0 {
+			spec.Gap = v
+			break
+		}
+		r.Printf("%s%s  ✗ '%s' is not a gap%s\n", ui.Bg, ui.Error, text, ui.Reset)
+	}
+	lastCompound[angles] = spec
+
+	var rows []models.SteelProperty
+	var firstErr error
+	for _, p := range properties {
+		c, err := compound.Build(p, spec)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		rows = append(rows, c)
+	}
+	if len(rows) == 0 {
+		r.Printf("%s%s  ✗ %v%s\n", ui.Bg, ui.Error, firstErr, ui.Reset)
+		r.Printf("%s%s  Press any key to return to the table%s\n", ui.Bg, ui.TextDim, ui.Reset)
+		buffer := make([]byte, 16)
+		os.Stdin.Read(buffer)
+		return nil, "", false
+	}
+	title := "2/" + tableName + "/" + string(spec.Arrangement)
+	if spec.Gap > 0 {
+		title += "/" + formatInput(spec.Gap)
+	}
+	return rows, title, true
+}
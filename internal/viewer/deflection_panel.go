@@ -0,0 +1,124 @@
+package viewer
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"steel_tables/internal/calc"
+	"steel_tables/internal/models"
+	"steel_tables/internal/ui"
+)
+
+// deflectionFilter highlights the sections whose Ix satisfies a deflection
+// limit. The zero value highlights nothing.
+type deflectionFilter struct {
+	beam       calc.BeamLoading
+	limit      float64
+	point      calc.PointLoad
+	requiredIx float64
+}
+
+// active reports whether the filter should highlight rows.
+func (f deflectionFilter) active() bool {
+	return f.requiredIx > 0
+}
+
+// passes reports whether a section meets the deflection limit.
+func (f deflectionFilter) passes(p models.SteelProperty) bool {
+	return p.Ix >= f.requiredIx
+}
+
+// status describes the filter for the navigation footer.
+func (f deflectionFilter) status(properties []models.SteelProperty) string {
+	count := 0
+	for _, p := range properties {
+		if f.passes(p) {
+			count++
+		}
+	}
+	return fmt.Sprintf("%d pass L/%g (Ix ≥ %.1f)", count, f.limit, f.requiredIx)
+}
+
+// lastDeflection remembers the deflection inputs between uses of the panel.
+var lastDeflection = deflectionFilter{
+	beam:  calc.BeamLoading{Support: calc.SimplySupported},
+	limit: 250,
+}
+
+// runDeflectionPanel prompts for a beam and serviceability loads and returns
+// the filter to highlight passing sections. Esc at the first prompt clears
+// the highlight; Esc at a later prompt keeps the current filter.
+func runDeflectionPanel(tableName string, current deflectionFilter) deflectionFilter {
+	f := lastDeflection
+
+	r := ui.TerminalRenderer(true)
+	r.Print(ui.Bg + ui.Clear)
+	r.DrawTitleBox("DEFLECTION CHECK: "+tableName, "Enter accepts, Esc cancels (clears the highlight)")
+
+	for {
+		text, ok := r.Prompt("  Support (ss, cantilever, fixed) : ", supportInput(f.beam.Support))
+		if !ok {
+			return deflectionFilter{}
+		}
+		s, err := calc.ParseSupport(text)
+		if err == nil {
+			f.beam.Support = s
+			break
+		}
+		r.Printf("%s%s  ✗ %v%s\n", ui.Bg, ui.Error, err, ui.Reset)
+	}
+
+	fields := []selectField{
+		{"L   span (m)                     : ", &f.beam.Span},
+		{"w   service UDL (kN/m)           : ", &f.beam.UDL},
+		{"P   service point load (kN)      : ", &f.point.P},
+		{"a   load position (m)            : ", &f.point.A},
+		{"    deflection limit (L/…)       : ", &f.limit},
+	}
+	for _, field := range fields {
+		for {
+			text, ok := r.Prompt("  "+field.label, formatInput(*field.value))
+			if !ok {
+				return current
+			}
+			if text == "" {
+				*field.value = 0
+				break
+			}
+			v, err := strconv.ParseFloat(text, 64)
+			if err == nil {
+				*field.value = v
+				break
+			}
+			r.Printf("%s%s  ✗ '%s' is not a number%s\n", ui.Bg, ui.Error, text, ui.Reset)
+		}
+	}
+
+	f.beam.Points = nil
+	if f.point.P != 0 {
+		f.beam.Points = []calc.PointLoad{f.point}
+	}
+	required, err := calc.RequiredIx(f.beam, f.limit)
+	if err != nil {
+		r.Printf("%s%s  ✗ %v%s\n", ui.Bg, ui.Error, err, ui.Reset)
+		r.Printf("%s%s  Press any key to return to the table%s\n", ui.Bg, ui.TextDim, ui.Reset)
+		buffer := make([]byte, 16)
+		os.Stdin.Read(buffer)
+		return current
+	}
+	f.requiredIx = required
+	lastDeflection = f
+	return f
+}
+
+func supportInput(s calc.Support) string {
+	switch s {
+	case calc.Cantilever:
+		return "cantilever"
+	case calc.FixedEnds:
+		return "fixed"
+	default:
+		return "ss"
+	}
+}
@@ -0,0 +1,119 @@
+package viewer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"steel_tables/internal/calc"
+	"steel_tables/internal/materials"
+	"steel_tables/internal/models"
+	"steel_tables/internal/ui"
+)
+
+// runDetailPanel prompts for a section in the current table, starting from
+// the top visible row, and shows its material and section capacities.
+// Returns when a key is pressed.
+func runDetailPanel(tableName string, properties []models.SteelProperty, top int) {
+	if len(properties) == 0 {
+		return
+	}
+	r := ui.TerminalRenderer(true)
+	r.Print(ui.Bg + ui.Clear)
+	r.DrawTitleBox("SECTION DETAIL: "+tableName, "Enter accepts, Esc cancels")
+
+	var p models.SteelProperty
+	initial := designation(properties[top].Section)
+	for {
+		text, ok := r.Prompt("  Section : ", initial)
+		if !ok {
+			return
+		}
+		if row, found := findRow(properties, text); found {
+			p = row
+			break
+		}
+		r.Printf("%s%s  ✗ no section '%s' in %s%s\n", ui.Bg, ui.Error, text, tableName, ui.Reset)
+		initial = text
+	}
+
+	m := materials.Of(p)
+	c := calc.Section(p)
+
+	var frame bytes.Buffer
+	r = ui.NewRenderer(&frame, r.Width, r.Height, true)
+	r.Print(ui.Bg + ui.Clear)
+	grade := strconv.Itoa(p.Grade) + " (table values)"
+	if m.Grade != nil {
+		grade = m.Grade.String()
+	}
+	r.DrawTitleBox(p.Section, "Grade "+grade)
+	drawn := 0
+
+	thickness := func(t float64) string {
+		if t == 0 {
+			return "-"
+		}
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	}
+	r.DrawTextTable([]string{"Material", "t (mm)", "fy (MPa)"}, [][]string{
+		{"Flange", thickness(m.FlangeThickness), fmt.Sprintf("%.0f", m.Flange)},
+		{"Web", thickness(m.WebThickness), fmt.Sprintf("%.0f", m.Web)},
+		{"Design", "", fmt.Sprintf("%.0f", m.Fy())},
+	}, func(i int) bool { return i == 2 })
+	r.Printf("%s%s  fu = %.0f MPa%s\n\n", ui.Bg, ui.TextDim, m.Fu, ui.Reset)
+	drawn += 7
+
+	if m.Grade != nil && len(m.Grade.Bands) > 1 {
+		// The grade's thickness bands, marking those the section falls in.
+		var rows [][]string
+		var used []bool
+		for i, b := range m.Grade.Bands {
+			rows = append(rows, []string{m.Grade.Range(i), fmt.Sprintf("%.0f", b.Fy), fmt.Sprintf("%.0f", b.Fu)})
+			used = append(used, (m.FlangeThickness > 0 && m.Grade.Band(m.FlangeThickness) == b) ||
+				(m.WebThickness > 0 && m.Grade.Band(m.WebThickness) == b))
+		}
+		r.DrawTextTable([]string{"t (mm)", "fy (MPa)", "fu (MPa)"}, rows, func(i int) bool { return used[i] })
+		r.Println()
+		drawn += len(rows) + 2
+	}
+
+	r.DrawTextTable([]string{"Capacity", "Value", "Clause"}, [][]string{
+		{"φMsx", fmt.Sprintf("%.1f kNm", c.PhiMsx), "5.2"},
+		{"φMsy", fmt.Sprintf("%.1f kNm", c.PhiMsy), "5.2"},
+		{"φNs", fmt.Sprintf("%.0f kN", c.PhiNs), "6.2"},
+		{"φNt", fmt.Sprintf("%.0f kN", c.PhiNt), "7.2"},
+		{"φVv", fmt.Sprintf("%.0f kN", c.PhiVv), "5.11"},
+	}, nil)
+	drawn += 6
+
+	r.BlankLines(r.Height - 7 - drawn)
+	r.Printf("%s%s  Press any key to return to the table%s\n", ui.Bg, ui.TextDim, ui.Reset)
+	os.Stdout.Write(frame.Bytes())
+
+	buffer := make([]byte, 16)
+	os.Stdin.Read(buffer)
+}
+
+// designation returns a section name without its grade suffix.
+func designation(section string) string {
+	section, _ = models.SplitGrade(section)
+	return strings.TrimSpace(section)
+}
+
+// findRow finds a row by designation, ignoring case, spaces and the grade
+// suffix.
+func findRow(properties []models.SteelProperty, text string) (models.SteelProperty, bool) {
+	key := func(s string) string {
+		return strings.ToUpper(strings.ReplaceAll(designation(s), " ", ""))
+	}
+	want := key(text)
+	for _, p := range properties {
+		if key(p.Section) == want {
+			return p, true
+		}
+	}
+	return models.SteelProperty{}, false
+}
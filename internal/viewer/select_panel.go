@@ -0,0 +1,121 @@
+package viewer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+
+	"steel_tables/internal/models"
+	"steel_tables/internal/selector"
+	"steel_tables/internal/ui"
+)
+
+// lastRequirements remembers the selector inputs between uses of the panel.
+var lastRequirements = selector.Requirements{AlphaM: 1, DeflectionLimit: 250}
+
+// selectField is one prompt in the selector panel.
+type selectField struct {
+	label string
+	value *float64
+}
+
+// runSelectPanel prompts for design actions and lists the lightest sections
+// in the current table that satisfy them. Returns when a key is pressed.
+func runSelectPanel(tableName string, properties []models.SteelProperty) {
+	req := lastRequirements
+	fields := []selectField{
+		{"M*  design moment (kNm)      : ", &req.M},
+		{"V*  design shear (kN)        : ", &req.V},
+		{"N*  axial, +comp (kN)        : ", &req.N},
+		{"Le  effective length (m)     : ", &req.Le},
+		{"αm  moment modification      : ", &req.AlphaM},
+		{"L   span for deflection (m)  : ", &req.Span},
+		{"w   service UDL (kN/m)       : ", &req.ServiceLoad},
+		{"    deflection limit (L/…)   : ", &req.DeflectionLimit},
+	}
+
+	r := ui.TerminalRenderer(true)
+	r.Print(ui.Bg + ui.Clear)
+	r.DrawTitleBox("SECTION SELECTOR: "+tableName, "Enter accepts, Esc cancels")
+
+	for _, f := range fields {
+		for {
+			text, ok := r.Prompt("  "+f.label, formatInput(*f.value))
+			if !ok {
+				return
+			}
+			if text == "" {
+				*f.value = 0
+				break
+			}
+			v, err := strconv.ParseFloat(text, 64)
+			if err == nil {
+				*f.value = v
+				break
+			}
+			r.Printf("%s%s  ✗ '%s' is not a number%s\n", ui.Bg, ui.Error, text, ui.Reset)
+		}
+	}
+	lastRequirements = req
+
+	candidates, err := selector.Evaluate(tableName, properties, req)
+	var passing []selector.Candidate
+	for _, c := range candidates {
+		if c.Passes() {
+			passing = append(passing, c)
+		}
+	}
+	selector.Rank(passing)
+
+	var frame bytes.Buffer
+	r = ui.NewRenderer(&frame, r.Width, r.Height, true)
+	r.Print(ui.Bg + ui.Clear)
+	r.DrawTitleBox("SECTION SELECTOR: "+tableName,
+		fmt.Sprintf("%d of %d sections pass | lightest first", len(passing), len(candidates)))
+
+	maxRows := r.Height - 9
+	if maxRows < 3 {
+		maxRows = 3
+	}
+	drawn := 0
+	if err != nil {
+		r.Printf("%s%s  ✗ %v%s\n", ui.Bg, ui.Error, err, ui.Reset)
+		drawn = 1
+	} else if len(passing) == 0 {
+		r.Printf("%s%s  No section in %s meets the requirements.%s\n", ui.Bg, ui.Warning, tableName, ui.Reset)
+		drawn = 1
+	} else {
+		if len(passing) > maxRows {
+			passing = passing[:maxRows]
+		}
+		headers := []string{"#", "Section", "Weight (kg/m)"}
+		for _, u := range passing[0].Checks {
+			headers = append(headers, u.Name)
+		}
+		headers = append(headers, "Governing")
+		rows := make([][]string, len(passing))
+		for i, c := range passing {
+			row := []string{fmt.Sprintf("%d", i+1), c.Property.Section, fmt.Sprintf("%.1f", c.Property.Weight)}
+			for _, u := range c.Checks {
+				row = append(row, fmt.Sprintf("%.3f", u.Ratio))
+			}
+			rows[i] = append(row, c.Governing.Label())
+		}
+		r.DrawTextTable(headers, rows, func(i int) bool { return i == 0 })
+		drawn = len(rows) + 1
+	}
+	r.BlankLines(r.Height - 7 - drawn)
+	r.Printf("%s%s  Press any key to return to the table%s\n", ui.Bg, ui.TextDim, ui.Reset)
+	os.Stdout.Write(frame.Bytes())
+
+	buffer := make([]byte, 16)
+	os.Stdin.Read(buffer)
+}
+
+func formatInput(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
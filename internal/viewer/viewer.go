@@ -2,13 +2,11 @@
 package viewer
 
 import (
-	"encoding/json"
-	"fmt"
+	"bytes"
 	"log"
 	"os"
-	"path/filepath"
-	"strings"
 
+	"steel_tables/internal/catalog"
 	"steel_tables/internal/columns"
 	"steel_tables/internal/models"
 	"steel_tables/internal/ui"
@@ -16,33 +14,37 @@ import (
 
 // DisplayTable shows an interactive table view with scrolling and paging.
 // Returns true if user wants to return to menu, false to quit.
-func DisplayTable(filePath string) bool {
-	data, err := os.ReadFile(filePath)
+func DisplayTable(tableName string) bool {
+	properties, err := catalog.Load(tableName)
 	if err != nil {
-		log.Fatal("Error reading file:", err)
-	}
-
-	var properties []models.SteelProperty
-	if err := json.Unmarshal(data, &properties); err != nil {
-		log.Fatal("Error parsing JSON:", err)
+		log.Fatal("Error loading table: ", err)
 	}
+	return displayProperties(catalog.TableFile(tableName), tableName, properties)
+}
 
+// displayProperties runs the interactive view of a list of rows. Returns
+// true if the user wants to go back (to the menu, or to the table a view of
+// double sections came from), false to quit.
+func displayProperties(title, tableName string, properties []models.SteelProperty) bool {
 	allColumns := columns.GetAll()
 	availableColumns := columns.FilterAvailable(allColumns, properties)
+	availableColumns = append(availableColumns, columns.FilterAvailable(columns.GetCapacities(), properties)...)
 
 	currentPage := 0
 	scrollRow := 0
+	var deflection deflectionFilter
 
 	for {
-		termHeight := ui.GetTerminalHeight()
-		maxCols := ui.GetMaxCols()
+		var frame bytes.Buffer
+		r := ui.NewRenderer(&frame, ui.GetTerminalWidth(), ui.GetTerminalHeight(), true)
+		maxCols := r.MaxCols()
 
-		visibleRows := termHeight - 10
+		visibleRows := r.Height - 10
 		if visibleRows < 3 {
 			visibleRows = 3
 		}
 
-		fmt.Print(ui.Bg + ui.Clear)
+		r.Print(ui.Bg + ui.Clear)
 
 		startCol := currentPage * maxCols
 		endCol := startCol + maxCols
@@ -67,18 +69,23 @@ func DisplayTable(filePath string) bool {
 		}
 		visibleProperties := properties[scrollRow:endRow]
 
-		ui.DrawHeader(filepath.Base(filePath), currentPage+1, totalPages, len(properties))
-		ui.DrawColumnHeaders(currentColumns)
-		ui.DrawDataRowsOffset(visibleProperties, currentColumns, scrollRow)
+		r.DrawHeader(title, currentPage+1, totalPages, len(properties))
+		r.DrawColumnHeaders(currentColumns)
+		var highlight func(models.SteelProperty) bool
+		status := ""
+		if deflection.active() {
+			highlight = deflection.passes
+			status = deflection.status(properties)
+		} else if canCompound(properties) {
+			status = "b double sections"
+		}
+		r.DrawDataRowsHighlight(visibleProperties, currentColumns, scrollRow, highlight)
 
 		// Fill empty lines
-		drawnRows := len(visibleProperties)
-		termWidth := ui.GetTerminalWidth()
-		for i := drawnRows; i < visibleRows; i++ {
-			fmt.Printf("%s%s%s\n", ui.Bg, strings.Repeat(" ", termWidth), ui.Reset)
-		}
+		r.BlankLines(visibleRows - len(visibleProperties))
 
-		ui.DrawNavigationFooter(currentPage, totalPages, scrollRow, endRow, len(properties))
+		r.DrawNavigationFooterStatus(currentPage, totalPages, scrollRow, endRow, len(properties), status)
+		os.Stdout.Write(frame.Bytes())
 
 		// Handle input
 		buffer := make([]byte, 128)
@@ -93,6 +100,17 @@ func DisplayTable(filePath string) bool {
 			return false
 		case len(input) == 1 && (input[0] == 'm' || input[0] == 'M'):
 			return true
+		case len(input) == 1 && (input[0] == 's' || input[0] == 'S'):
+			runSelectPanel(tableName, properties)
+		case len(input) == 1 && (input[0] == 'i' || input[0] == 'I'):
+			runDetailPanel(tableName, properties, scrollRow)
+		case len(input) == 1 && (input[0] == 'd' || input[0] == 'D'):
+			deflection = runDeflectionPanel(tableName, deflection)
+		case len(input) == 1 && (input[0] == 'b' || input[0] == 'B') && canCompound(properties):
+			rows, name, ok := runCompoundPanel(tableName, properties)
+			if ok && !displayProperties(name, name, rows) {
+				return false
+			}
 		case len(input) == 1 && input[0] == '>':
 			if endCol < len(availableColumns) {
 				currentPage++
@@ -137,23 +155,30 @@ func DisplayTable(filePath string) bool {
 }
 
 // PrintTableOnce prints the table non-interactively (for CLI mode).
-func PrintTableOnce(filePath string) {
-	data, err := os.ReadFile(filePath)
+// Without colour the table is written as plain aligned text with every
+// available column on one line, so it can be redirected or piped.
+func PrintTableOnce(r *ui.Renderer, tableName string) {
+	properties, err := catalog.Load(tableName)
 	if err != nil {
-		log.Fatal("Error reading file:", err)
-	}
-
-	var properties []models.SteelProperty
-	if err := json.Unmarshal(data, &properties); err != nil {
-		log.Fatal("Error parsing JSON:", err)
+		log.Fatal("Error loading table: ", err)
 	}
+	PrintPropertiesOnce(r, catalog.TableFile(tableName), properties)
+}
 
+// PrintPropertiesOnce prints a list of rows non-interactively under a
+// title, in the same layout as PrintTableOnce.
+func PrintPropertiesOnce(r *ui.Renderer, title string, properties []models.SteelProperty) {
 	allColumns := columns.GetAll()
 	availableColumns := columns.FilterAvailable(allColumns, properties)
-	maxCols := ui.GetMaxCols()
+	if !r.Color {
+		r.DrawPlainTable(properties, availableColumns)
+		return
+	}
+
+	maxCols := r.MaxCols()
 	totalPages := (len(availableColumns) + maxCols - 1) / maxCols
 
-	fmt.Print(ui.Bg)
+	r.Print(ui.Bg)
 
 	for i := 0; i < totalPages; i++ {
 		startCol := i * maxCols
@@ -162,12 +187,12 @@ func PrintTableOnce(filePath string) {
 			endCol = len(availableColumns)
 		}
 		currentColumns := availableColumns[startCol:endCol]
-		ui.DrawHeader(filepath.Base(filePath), i+1, totalPages, len(properties))
-		ui.DrawColumnHeaders(currentColumns)
-		ui.DrawDataRows(properties, currentColumns)
+		r.DrawHeader(title, i+1, totalPages, len(properties))
+		r.DrawColumnHeaders(currentColumns)
+		r.DrawDataRows(properties, currentColumns)
 		if i < totalPages-1 {
-			fmt.Println()
+			r.Println()
 		}
 	}
-	fmt.Print(ui.Reset)
+	r.Print(ui.Reset)
 }